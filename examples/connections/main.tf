@@ -22,28 +22,24 @@ variable "fqdn" {
   type    = string
 }
 
+# every resource below belongs to this organization and environment
 provider "aptible" {
-  host = var.aptible_host
+  host            = var.aptible_host
+  organization_id = var.org_id
+  environment_id  = var.env_id
 }
 
 resource "aptible_aws_vpc" "network" {
-  environment_id  = var.env_id
-  organization_id = var.org_id
   name            = "conn" # optional
 }
 
 resource "aptible_aws_acm" "cert" {
-  environment_id    = var.env_id
-  organization_id   = var.org_id
-
   fqdn              = var.fqdn
 
   validation_method = "DNS" # optional
 }
 
 resource "aptible_aws_ecs_web" "web" {
-  environment_id      = var.org_id
-  organization_id     = var.env_id
   vpc_name            = aptible_aws_vpc.network.name
 
   name                = "nginx"
@@ -58,3 +54,17 @@ resource "aptible_aws_ecs_web" "web" {
   container_port      = 80 # optional
   environment_secrets = {} # optional
 }
+
+resource "aptible_aws_rds" "db" {
+  vpc_name        = aptible_aws_vpc.network.name
+
+  name            = "conn-db"
+  engine          = "postgres"
+  engine_version  = "14"
+}
+
+# a connection managed on its own, without changing the service definition
+resource "aptible_connection" "web_db" {
+  asset_id          = aptible_aws_ecs_web.web.id
+  outgoing_asset_id = aptible_aws_rds.db.id
+}
@@ -10,21 +10,29 @@ provider "aptible" {
   host = "cloud-api.cloud.aptible.com"
 }
 
+# either id or name looks up an organization or environment
 data "aptible_organization" "org" {
-  id = "2253ae98-d65a-4180-aceb-8419b7416677"
+  name = "my-org"
 }
 
 data "aptible_environment" "env" {
-  id = "238930f4-0750-4f55-b43c-e1a11c437e23"
+  name   = "my-env"
   org_id = data.aptible_organization.org.id
 }
 
-resource "aptible_null_simple" "network" {
-  environment_id          = data.aptible_environment.env.id
-  organization_id         = data.aptible_organization.org.id
+resource "aptible_asset" "network" {
+  environment_id  = data.aptible_environment.env.id
+  organization_id = data.aptible_organization.org.id
 
-  asset_type = "simple"
   asset_platform = "null"
+  asset_type     = "simple"
+  asset_version  = "latest" # optional
 
-  name                   = "my_null"
+  parameters = jsonencode({
+    name = "my_null"
+  })
+}
+
+output "null_outputs" {
+  value = aptible_asset.network.outputs
 }
@@ -1,11 +1,17 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
 
 	cac "github.com/aptible/cloud-api-clients/clients/go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -13,33 +19,86 @@ import (
 
 // client - internal cac struct used only for this service with some common configuration
 type Client struct {
-	ctx context.Context
-
 	apiClient *cac.APIClient
 	debug     bool
 	token     string
+	redactor  *Redactor
+}
+
+// DebugEnabled - whether terraform logs the provider at debug level or finer, as TF_LOG_PROVIDER or else
+// TF_LOG set it. Requests and responses are only logged then, off by default
+func DebugEnabled() bool {
+	level := os.Getenv("TF_LOG_PROVIDER")
+	if level == "" {
+		level = os.Getenv("TF_LOG")
+	}
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "DEBUG", "TRACE", "JSON":
+		return true
+	default:
+		return false
+	}
+}
+
+// Option - optional configuration applied by NewClient
+type Option func(*options)
+
+type options struct {
+	retry           RetryConfig
+	sensitiveFields []string
+}
+
+// WithRetryConfig - override the retry budget used for transient API failures
+func WithRetryConfig(retry RetryConfig) Option {
+	return func(o *options) {
+		o.retry = retry
+	}
+}
+
+// WithSensitiveFields - mask these fields, on top of DefaultSensitiveFields, wherever they appear in
+// logged request and response bodies, e.g. the attributes marked sensitive in resource schemas
+func WithSensitiveFields(fields ...string) Option {
+	return func(o *options) {
+		o.sensitiveFields = append(o.sensitiveFields, fields...)
+	}
 }
 
 // NewClient - generate a new cloud api cloud_api_client
-func NewClient(debug bool, host string, token string) CloudClient {
+func NewClient(debug bool, host string, token string, opts ...Option) CloudClient {
+	o := options{retry: DefaultRetryConfig()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	config := cac.NewConfiguration()
 	config.Host = host
 	config.Scheme = "https"
+	// an explicit scheme is allowed so the provider can target a local api, e.g. http://localhost:8000
+	if u, err := url.Parse(host); err == nil && u.Scheme != "" && u.Host != "" {
+		config.Host = u.Host
+		config.Scheme = u.Scheme
+	}
+	config.HTTPClient = &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, o.retry),
+	}
 
 	apiClient := cac.NewAPIClient(config)
 
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, cac.ContextAccessToken, token)
-
 	return &Client{
-		ctx:       ctx,
 		apiClient: apiClient,
 
-		debug: debug,
-		token: token,
+		debug:    debug,
+		token:    token,
+		redactor: NewRedactor(o.sensitiveFields, token),
 	}
 }
 
+// requestContext - derive the context of an api call from the caller's, so terraform can cancel
+// in-flight requests, and attach the access token
+func (c *Client) requestContext(tfctx context.Context) context.Context {
+	return context.WithValue(tfctx, cac.ContextAccessToken, c.token)
+}
+
 func (c *Client) PrintRequestParams(tfctx context.Context, params interface{}) {
 	if !c.debug {
 		return
@@ -51,40 +110,72 @@ func (c *Client) PrintRequestParams(tfctx context.Context, params interface{}) {
 		return
 	}
 
-	tflog.Debug(tfctx, "REQUEST PARAMS", map[string]interface{}{"params": string(out)})
+	tflog.Debug(tfctx, "REQUEST PARAMS", map[string]interface{}{"params": string(c.redactor.JSON(out))})
 }
 
-func (c *Client) HandleResponse(tfctx context.Context, r *http.Response, err error) error {
+// HandleResponse - log the exchange and turn a failed call into an *APIError naming the operation
+func (c *Client) HandleResponse(tfctx context.Context, operation string, r *http.Response, err error) error {
 	if r == nil {
-		fmt.Printf("The HTTP response is nil which means the request was never made.  Are you sure your API domain is set properly? (%s)\n", c.apiClient.GetConfig().Host)
-		return nil
+		apiErr := newAPIError(operation, nil, err)
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return apiErr
+		}
+		apiErr.Message = fmt.Sprintf(
+			"no response, the request was never made. Is the api host set properly? (%s)",
+			c.apiClient.GetConfig().Host,
+		)
+		if err != nil {
+			apiErr.Message += ": " + err.Error()
+		}
+		return apiErr
 	}
-	dump := c.PrintResponse(tfctx, r)
+	c.PrintResponse(tfctx, r)
 	if err != nil {
-		return fmt.Errorf("%s: %s", err.Error(), dump)
+		return newAPIError(operation, r, err)
 	}
 
 	return nil
 }
 
+// PrintResponse - log the exchange with credentials and secrets masked, the body of r can still be read afterwards
 func (c *Client) PrintResponse(tfctx context.Context, r *http.Response) string {
 	if !c.debug {
 		return ""
 	}
 
-	reqDump, err := httputil.DumpRequestOut(r.Request, false)
-	if err != nil {
-		tflog.Error(tfctx, err.Error())
+	if r.Request != nil {
+		req := r.Request.Clone(tfctx)
+		req.Header = c.redactor.Headers(req.Header)
+		reqDump, err := httputil.DumpRequestOut(req, false)
+		if err != nil {
+			tflog.Error(tfctx, err.Error())
+		}
+
+		tflog.Debug(tfctx, "REQUEST", map[string]interface{}{"out": c.redactor.String(string(reqDump))})
 	}
 
-	tflog.Debug(tfctx, "REQUEST", map[string]interface{}{"out": string(reqDump)})
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			tflog.Error(tfctx, err.Error())
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
 
-	respDump, err := httputil.DumpResponse(r, true)
+	redacted := *r
+	redacted.Header = c.redactor.Headers(r.Header)
+	redacted.Body = nil
+	respDump, err := httputil.DumpResponse(&redacted, false)
 	if err != nil {
 		tflog.Error(tfctx, err.Error())
 	}
 
-	dump := string(respDump)
+	dump := c.redactor.String(string(respDump))
+	if len(body) > 0 {
+		dump += string(c.redactor.JSON(body))
+	}
 	tflog.Debug(tfctx, "RESPONSE", map[string]interface{}{"out": dump})
 	return dump
 }
@@ -93,9 +184,9 @@ func (c *Client) ListEnvironments(tfctx context.Context, orgId string) ([]cac.En
 	request := c.
 		apiClient.
 		OrganizationsApi.
-		OrganizationGetEnvironments(c.ctx, orgId)
+		OrganizationGetEnvironments(c.requestContext(tfctx), orgId)
 	env, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "ListEnvironments", r, err)
 	return env, err
 }
 
@@ -103,10 +194,10 @@ func (c *Client) CreateEnvironment(tfctx context.Context, orgId string, params c
 	request := c.
 		apiClient.
 		EnvironmentsApi.
-		EnvironmentCreate(c.ctx, orgId).
+		EnvironmentCreate(c.requestContext(tfctx), orgId).
 		EnvironmentInput(params)
 	env, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "CreateEnvironment", r, err)
 	return env, err
 }
 
@@ -115,12 +206,23 @@ func (c *Client) DescribeEnvironment(tfctx context.Context, orgId string, envId
 		apiClient.
 		EnvironmentsApi.
 		EnvironmentGet(
-			c.ctx,
+			c.requestContext(tfctx),
 			envId,
 			orgId,
 		).
 		Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "DescribeEnvironment", r, err)
+	return env, err
+}
+
+func (c *Client) UpdateEnvironment(tfctx context.Context, orgId string, envId string, params cac.EnvironmentInput) (*cac.EnvironmentOutput, error) {
+	request := c.
+		apiClient.
+		EnvironmentsApi.
+		EnvironmentUpdate(c.requestContext(tfctx), envId, orgId).
+		EnvironmentInput(params)
+	env, r, err := request.Execute()
+	err = c.HandleResponse(tfctx, "UpdateEnvironment", r, err)
 	return env, err
 }
 
@@ -129,12 +231,12 @@ func (c *Client) DestroyEnvironment(tfctx context.Context, orgId string, envId s
 		apiClient.
 		EnvironmentsApi.
 		EnvironmentDelete(
-			c.ctx,
+			c.requestContext(tfctx),
 			envId,
 			orgId,
 		).
 		Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "DestroyEnvironment", r, err)
 	return err
 }
 
@@ -142,10 +244,10 @@ func (c *Client) CreateOrg(tfctx context.Context, orgId string, params cac.Organ
 	request := c.
 		apiClient.
 		OrganizationsApi.
-		OrganizationUpdate(c.ctx, orgId).
+		OrganizationUpdate(c.requestContext(tfctx), orgId).
 		OrganizationInput(params)
 	org, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "CreateOrg", r, err)
 	return org, err
 }
 
@@ -153,9 +255,9 @@ func (c *Client) FindOrg(tfctx context.Context, orgId string) (*cac.Organization
 	org, r, err := c.
 		apiClient.
 		OrganizationsApi.
-		OrganizationGet(c.ctx, orgId).
+		OrganizationGet(c.requestContext(tfctx), orgId).
 		Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "FindOrg", r, err)
 	return org, err
 }
 
@@ -164,79 +266,103 @@ func (c *Client) CreateAsset(tfctx context.Context, orgId string, envId string,
 
 	request := c.apiClient.AssetsApi.
 		AssetCreate(
-			c.ctx,
+			c.requestContext(tfctx),
 			envId,
 			orgId,
 		).AssetInput(params)
 	asset, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "CreateAsset", r, err)
 	return asset, err
 }
 
-func (c *Client) DestroyAsset(tfctx context.Context, orgId string, envId string, assetId string) error {
+func (c *Client) DestroyAsset(tfctx context.Context, orgId string, envId string, assetId string) (*cac.AssetOutput, error) {
 	request := c.
 		apiClient.
 		AssetsApi.
 		AssetDelete(
-			c.ctx,
+			c.requestContext(tfctx),
 			assetId,
 			envId,
 			orgId,
 		)
-	_, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
-	return err
+	asset, r, err := request.Execute()
+	err = c.HandleResponse(tfctx, "DestroyAsset", r, err)
+	return asset, err
 }
 
 func (c *Client) UpdateAsset(tfctx context.Context, assetId string, envId string, orgId string, params cac.AssetInput) (*cac.AssetOutput, error) {
 	request := c.apiClient.AssetsApi.
-		AssetUpdate(c.ctx, assetId, envId, orgId).
+		AssetUpdate(c.requestContext(tfctx), assetId, envId, orgId).
 		AssetInput(params)
 	asset, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "UpdateAsset", r, err)
 	return asset, err
 }
 
 func (c *Client) ListAssets(tfctx context.Context, orgId string, envId string) ([]cac.AssetOutput, error) {
 	request := c.apiClient.EnvironmentsApi.EnvironmentGetAssets(
-		c.ctx,
+		c.requestContext(tfctx),
 		envId,
 		orgId,
 	)
 	assets, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "ListAssets", r, err)
 	return assets, err
 }
 
 func (c *Client) DescribeAsset(tfctx context.Context, orgId string, envId string, assetId string) (*cac.AssetOutput, error) {
 	request := c.apiClient.AssetsApi.
 		AssetGet(
-			c.ctx,
+			c.requestContext(tfctx),
 			assetId,
 			envId,
 			orgId,
 		)
 	asset, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "DescribeAsset", r, err)
 	return asset, err
 }
 
 func (c *Client) ListOrgs(tfctx context.Context) ([]cac.OrganizationOutput, error) {
-	request := c.apiClient.OrganizationsApi.OrganizationList(c.ctx)
+	request := c.apiClient.OrganizationsApi.OrganizationList(c.requestContext(tfctx))
 	orgs, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "ListOrgs", r, err)
 	return orgs, err
 }
 
-func (c *Client) ListOperationsByAsset(tfctx context.Context, orgId string, assetId string) ([]cac.OperationOutput, error) {
+func (c *Client) ListOperationsByAsset(tfctx context.Context, orgId string, assetId string) ([]Operation, error) {
 	request := c.
 		apiClient.
 		OrganizationsApi.
-		OrganizationGetOperations(c.ctx, orgId).
+		OrganizationGetOperations(c.requestContext(tfctx), orgId).
 		AssetId(assetId)
-	ops, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
-	return ops, err
+	_, r, err := request.Execute()
+	if err = c.HandleResponse(tfctx, "ListOperationsByAsset", r, err); err != nil {
+		return nil, err
+	}
+
+	ops := []Operation{}
+	if err := decodeBody(r, &ops); err != nil {
+		return nil, newAPIError("ListOperationsByAsset", r, err)
+	}
+	return ops, nil
+}
+
+func (c *Client) DescribeOperation(tfctx context.Context, orgId string, operationId string) (*Operation, error) {
+	request := c.
+		apiClient.
+		OperationsApi.
+		OperationGet(c.requestContext(tfctx), operationId, orgId)
+	_, r, err := request.Execute()
+	if err = c.HandleResponse(tfctx, "DescribeOperation", r, err); err != nil {
+		return nil, err
+	}
+
+	op := &Operation{}
+	if err := decodeBody(r, op); err != nil {
+		return nil, newAPIError("DescribeOperation", r, err)
+	}
+	return op, nil
 }
 
 func (c *Client) ListAssetBundles(tfctx context.Context, orgId string, envId string) ([]cac.AssetBundle, error) {
@@ -244,12 +370,12 @@ func (c *Client) ListAssetBundles(tfctx context.Context, orgId string, envId str
 		apiClient.
 		EnvironmentsApi.
 		EnvironmentGetAllowedAssetBundles(
-			c.ctx,
+			c.requestContext(tfctx),
 			envId,
 			orgId,
 		)
 	bundles, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "ListAssetBundles", r, err)
 	return bundles, err
 }
 
@@ -258,14 +384,14 @@ func (c *Client) GetConnection(tfctx context.Context, orgId, envId, assetId, con
 		apiClient.
 		ConnectionsApi.
 		ConnectionGet(
-			c.ctx,
+			c.requestContext(tfctx),
 			assetId,
 			envId,
 			connectionId,
 			orgId,
 		)
 	conn, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "GetConnection", r, err)
 	return conn, err
 }
 
@@ -274,14 +400,14 @@ func (c *Client) CreateConnection(tfctx context.Context, orgId, envId, assetId s
 		apiClient.
 		ConnectionsApi.
 		ConnectionCreate(
-			c.ctx,
+			c.requestContext(tfctx),
 			assetId,
 			envId,
 			orgId,
 		).
 		ConnectionInput(params)
 	conn, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "CreateConnection", r, err)
 	return conn, err
 }
 
@@ -290,13 +416,13 @@ func (c *Client) DestroyConnection(tfctx context.Context, orgId, envId, assetId,
 		apiClient.
 		ConnectionsApi.
 		ConnectionDelete(
-			c.ctx,
+			c.requestContext(tfctx),
 			assetId,
 			connectionId,
 			envId,
 			orgId,
 		)
 	_, r, err := request.Execute()
-	err = c.HandleResponse(tfctx, r, err)
+	err = c.HandleResponse(tfctx, "DestroyConnection", r, err)
 	return err
 }
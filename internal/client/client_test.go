@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientUsesCallerContext(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	c := NewClient(false, ts.URL, "token")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.ListOrgs(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestDebugEnabled(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", "")
+	assert.False(t, DebugEnabled())
+
+	for level, enabled := range map[string]bool{"info": false, "WARN": false, "debug": true, "TRACE": true, "json": true} {
+		t.Setenv("TF_LOG", level)
+		assert.Equal(t, enabled, DebugEnabled(), level)
+	}
+
+	// the provider specific level wins
+	t.Setenv("TF_LOG", "trace")
+	t.Setenv("TF_LOG_PROVIDER", "error")
+	assert.False(t, DebugEnabled())
+}
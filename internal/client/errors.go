@@ -0,0 +1,167 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+)
+
+// requestIdHeaders - headers the api (or the load balancer in front of it) uses to identify a request
+var requestIdHeaders = []string{"X-Request-Id", "X-Amzn-Trace-Id"}
+
+// APIError - a failed call to the cloud api
+type APIError struct {
+	// Operation - the client method that failed, e.g. CreateAsset
+	Operation string
+	// StatusCode - http status of the response, zero when no response was received
+	StatusCode int
+	// Code - machine readable error code from the api, e.g. value_error.missing
+	Code string
+	// Message - human readable message from the api, validation errors are joined with their location
+	Message string
+	// RequestId - identifier of the request, quote it when contacting support
+	RequestId string
+	// Err - the underlying error returned by the generated client or the transport
+	Err error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Operation != "" {
+		b.WriteString(e.Operation)
+		b.WriteString(": ")
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+		if e.Message != "" {
+			b.WriteString(": ")
+		}
+	}
+	b.WriteString(e.Message)
+	if e.RequestId != "" {
+		fmt.Fprintf(&b, " (request id: %s)", e.RequestId)
+	}
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound - the api reported the requested object does not exist
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict - the api rejected the request because it conflicts with the current state of an object
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsValidation - the api rejected the request body or parameters
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity) || hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized - the token is missing, invalid or not allowed to perform the request
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized) || hasStatus(err, http.StatusForbidden)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// errorBody - the shapes of error responses returned by the api, detail is either a string or a
+// list of validation errors
+type errorBody struct {
+	Detail  json.RawMessage `json:"detail"`
+	Code    string          `json:"code"`
+	Message string          `json:"message"`
+}
+
+type validationError struct {
+	Loc  []interface{} `json:"loc"`
+	Msg  string        `json:"msg"`
+	Type string        `json:"type"`
+}
+
+// newAPIError - build an APIError from the result of an api call, r may be nil when the request
+// never made it to the api
+func newAPIError(operation string, r *http.Response, err error) *APIError {
+	apiErr := &APIError{Operation: operation, Err: err}
+	if r == nil {
+		if err != nil {
+			apiErr.Message = err.Error()
+		}
+		return apiErr
+	}
+
+	apiErr.StatusCode = r.StatusCode
+	for _, header := range requestIdHeaders {
+		if id := r.Header.Get(header); id != "" {
+			apiErr.RequestId = id
+			break
+		}
+	}
+
+	// the generated client only reports the http status, the details are in the body
+	var openAPIErr *cac.GenericOpenAPIError
+	if errors.As(err, &openAPIErr) {
+		apiErr.parseBody(openAPIErr.Body())
+		if apiErr.Message == "" && openAPIErr.Error() != r.Status {
+			apiErr.Message = openAPIErr.Error()
+		}
+	} else if err != nil {
+		apiErr.Message = err.Error()
+	}
+	return apiErr
+}
+
+func (e *APIError) parseBody(body []byte) {
+	var parsed errorBody
+	if len(body) == 0 || json.Unmarshal(body, &parsed) != nil {
+		return
+	}
+
+	e.Code = parsed.Code
+	if parsed.Message != "" {
+		e.Message = parsed.Message
+	}
+
+	var detail string
+	if json.Unmarshal(parsed.Detail, &detail) == nil && detail != "" {
+		e.Message = detail
+		return
+	}
+
+	var validations []validationError
+	if json.Unmarshal(parsed.Detail, &validations) != nil || len(validations) == 0 {
+		return
+	}
+	messages := []string{}
+	for _, v := range validations {
+		messages = append(messages, v.String())
+	}
+	e.Message = strings.Join(messages, "; ")
+	if e.Code == "" {
+		e.Code = validations[0].Type
+	}
+}
+
+// String - the location of the invalid field followed by the message, e.g. body.asset: field required
+func (v validationError) String() string {
+	loc := []string{}
+	for _, part := range v.Loc {
+		loc = append(loc, fmt.Sprint(part))
+	}
+	if len(loc) == 0 {
+		return v.Msg
+	}
+	return strings.Join(loc, ".") + ": " + v.Msg
+}
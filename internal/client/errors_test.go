@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func respondWith(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	ts := respondWith(http.StatusNotFound, `{"detail": "Asset not found"}`)
+	defer ts.Close()
+
+	c := NewClient(false, ts.URL, "token")
+	_, err := c.DescribeAsset(context.Background(), "org", "env", "asset")
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsConflict(err))
+	assert.Equal(t, "DescribeAsset", apiErr.Operation)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Asset not found", apiErr.Message)
+	assert.Equal(t, "req-123", apiErr.RequestId)
+	assert.Equal(t, "DescribeAsset: 404 Not Found: Asset not found (request id: req-123)", err.Error())
+}
+
+func TestAPIErrorConflict(t *testing.T) {
+	ts := respondWith(http.StatusConflict, `{"code": "asset_locked", "message": "Asset has an operation in progress"}`)
+	defer ts.Close()
+
+	c := NewClient(false, ts.URL, "token")
+	_, err := c.UpdateAsset(context.Background(), "asset", "env", "org", cac.AssetInput{})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, IsConflict(err))
+	assert.Equal(t, "asset_locked", apiErr.Code)
+	assert.Equal(t, "Asset has an operation in progress", apiErr.Message)
+}
+
+func TestAPIErrorValidation(t *testing.T) {
+	ts := respondWith(http.StatusUnprocessableEntity, `{"detail": [
+		{"loc": ["body", "asset_parameters", "name"], "msg": "field required", "type": "value_error.missing"},
+		{"loc": ["body", "asset_version"], "msg": "unknown version", "type": "value_error"}
+	]}`)
+	defer ts.Close()
+
+	c := NewClient(false, ts.URL, "token")
+	_, err := c.CreateAsset(context.Background(), "org", "env", cac.AssetInput{})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, IsValidation(err))
+	assert.Equal(t, "value_error.missing", apiErr.Code)
+	assert.Equal(
+		t,
+		"body.asset_parameters.name: field required; body.asset_version: unknown version",
+		apiErr.Message,
+	)
+}
+
+func TestAPIErrorWithoutBody(t *testing.T) {
+	ts := respondWith(http.StatusUnauthorized, ``)
+	defer ts.Close()
+
+	c := NewClient(false, ts.URL, "token")
+	_, err := c.ListOrgs(context.Background())
+
+	assert.True(t, IsUnauthorized(err))
+	assert.Equal(t, "ListOrgs: 401 Unauthorized (request id: req-123)", err.Error())
+}
+
+func TestAPIErrorNoResponse(t *testing.T) {
+	ts := respondWith(http.StatusOK, `[]`)
+	ts.Close()
+
+	c := NewClient(false, ts.URL, "token", WithRetryConfig(RetryConfig{}))
+	_, err := c.ListOrgs(context.Background())
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 0, apiErr.StatusCode)
+	assert.False(t, IsNotFound(err))
+	assert.Contains(t, apiErr.Message, "the request was never made")
+}
@@ -0,0 +1,722 @@
+/*
+Package fake is an in-memory implementation of client.CloudClient.
+
+It is meant to be injected as ProviderData so resources and data sources
+can be exercised without a live Cloud API. Assets go through the same
+status transitions as the backend (PENDING -> DEPLOYED -> DESTROYED),
+advancing one step every time they are described.
+*/
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/google/uuid"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+var _ client.CloudClient = &Client{}
+
+// DefaultPollsToSettle - number of DescribeAsset calls an asset stays in a transient status
+var DefaultPollsToSettle = 2
+
+// ErrNotFound - wrapped by every error about an unknown organization, environment, asset or connection
+var ErrNotFound = errors.New("not found")
+
+// OutputsFunc - builds the terraform outputs reported for a deployed asset
+type OutputsFunc func(asset cac.AssetOutput) map[string]cac.AssetTerraformOutput
+
+type Client struct {
+	mu sync.Mutex
+
+	// PollsToSettle - number of DescribeAsset calls before a transient status settles
+	PollsToSettle int
+	// SettleAfter - when set, transient statuses settle once this much time has passed instead
+	// of being driven by DescribeAsset calls, like the backend running terraform asynchronously
+	SettleAfter time.Duration
+	// Outputs - outputs reported once an asset is deployed, DefaultOutputs when nil
+	Outputs OutputsFunc
+	// Bundles - asset bundles allowed in every environment, DefaultBundles when nil
+	Bundles []cac.AssetBundle
+	// ProvisionPolls - number of DescribeEnvironment calls a created environment reports no aws account
+	// for, like the backend provisioning it asynchronously
+	ProvisionPolls int
+	// StaleStatus - when set, updated and destroyed assets keep reporting their previous status until
+	// their operation settles, like the backend before it picks the operation up
+	StaleStatus bool
+	// NewestOperationsFirst - list the operations of an asset newest first, the api doesn't promise an order
+	NewestOperationsFirst bool
+	// UnlistedOperationPolls - number of ListOperationsByAsset calls a new operation is left out of, like
+	// the backend recording it after the change was accepted
+	UnlistedOperationPolls int
+
+	orgs        map[string]*cac.OrganizationOutput
+	envs        map[string]*cac.EnvironmentOutput
+	envPolls    map[string]int
+	envData     map[string]map[string]interface{}
+	assets      map[string]*asset
+	operations  []*client.Operation
+	unlisted    map[string]int
+	connections map[string]*connection
+}
+
+type asset struct {
+	output       cac.AssetOutput
+	pending      bool
+	pendingPolls int
+	changedAt    time.Time
+	// settled - status the asset moves to once pendingPolls reaches zero
+	settled cac.AssetStatus
+}
+
+type connection struct {
+	output  cac.ConnectionOutput
+	assetId string
+}
+
+// NewClient - generate an empty fake cloud api client
+func NewClient() *Client {
+	return &Client{
+		PollsToSettle: DefaultPollsToSettle,
+		orgs:          map[string]*cac.OrganizationOutput{},
+		envs:          map[string]*cac.EnvironmentOutput{},
+		envPolls:      map[string]int{},
+		envData:       map[string]map[string]interface{}{},
+		assets:        map[string]*asset{},
+		connections:   map[string]*connection{},
+		unlisted:      map[string]int{},
+	}
+}
+
+// AddOrg - seed an organization
+func (c *Client) AddOrg(name string) cac.OrganizationOutput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	org := &cac.OrganizationOutput{
+		Id:             uuid.NewString(),
+		Name:           name,
+		ContactDetails: map[string]interface{}{},
+	}
+	c.orgs[org.Id] = org
+	return *org
+}
+
+// AddEnvironment - seed an environment, already provisioned with an aws account
+func (c *Client) AddEnvironment(orgId, name string) cac.EnvironmentOutput {
+	env, err := c.CreateEnvironment(context.Background(), orgId, cac.EnvironmentInput{Name: name})
+	if err != nil {
+		panic(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provision(c.envs[env.Id])
+	return *c.envs[env.Id]
+}
+
+// EnvironmentData - the data last sent for an environment, which the api doesn't return
+func (c *Client) EnvironmentData(envId string) map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.envData[envId]
+}
+
+// SetAssetStatus - force the status of an asset, e.g. to simulate an out-of-band failure
+func (c *Client) SetAssetStatus(assetId string, status cac.AssetStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if a, ok := c.assets[assetId]; ok {
+		a.output.Status = status
+		a.settled = status
+		a.pending = false
+		a.pendingPolls = 0
+		if status == cac.ASSETSTATUS_FAILED {
+			c.finishOperation(a, cac.OPERATIONSTATUS_FAILED)
+		} else {
+			c.finishOperation(a, cac.OPERATIONSTATUS_COMPLETE)
+		}
+	}
+}
+
+// FailAsset - fail the asset and the operation running on it with the given error and logs
+func (c *Client) FailAsset(assetId, message, logs string) {
+	c.SetAssetStatus(assetId, cac.ASSETSTATUS_FAILED)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if a, ok := c.assets[assetId]; ok {
+		if op := c.operation(a); op != nil {
+			op.Error = message
+			op.Logs = logs
+		}
+	}
+}
+
+// RemoveAsset - drop an asset entirely, as if it had been deleted out-of-band
+func (c *Client) RemoveAsset(assetId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.assets, assetId)
+}
+
+// Asset - current view of an asset, without advancing its status
+func (c *Client) Asset(assetId string) (cac.AssetOutput, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, ok := c.assets[assetId]
+	if !ok {
+		return cac.AssetOutput{}, false
+	}
+	return *a.snapshot(), true
+}
+
+// Operations - every operation recorded so far, oldest first
+func (c *Client) Operations() []client.Operation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ops := []client.Operation{}
+	for _, op := range c.operations {
+		ops = append(ops, *op)
+	}
+	return ops
+}
+
+func (c *Client) ListEnvironments(ctx context.Context, orgId string) ([]cac.EnvironmentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.orgs[orgId]; !ok {
+		return nil, notFound("organization", orgId)
+	}
+
+	envs := []cac.EnvironmentOutput{}
+	for _, env := range c.envs {
+		if env.Organization.Id == orgId {
+			envs = append(envs, *env)
+		}
+	}
+	return envs, nil
+}
+
+func (c *Client) DescribeEnvironment(ctx context.Context, orgId, envId string) (*cac.EnvironmentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	env, err := c.environment(orgId, envId)
+	if err != nil {
+		return nil, err
+	}
+	if c.envPolls[envId] > 0 {
+		c.envPolls[envId]--
+		if c.envPolls[envId] == 0 {
+			c.provision(env)
+		}
+	}
+	out := *env
+	return &out, nil
+}
+
+func (c *Client) CreateEnvironment(ctx context.Context, orgId string, params cac.EnvironmentInput) (*cac.EnvironmentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	org, ok := c.orgs[orgId]
+	if !ok {
+		return nil, notFound("organization", orgId)
+	}
+
+	env := &cac.EnvironmentOutput{
+		Id:           uuid.NewString(),
+		Name:         params.Name,
+		Description:  params.Description,
+		Organization: *org,
+	}
+	c.envs[env.Id] = env
+	c.envData[env.Id] = params.Data
+	if c.ProvisionPolls > 0 {
+		c.envPolls[env.Id] = c.ProvisionPolls
+	} else {
+		c.provision(env)
+	}
+
+	out := *env
+	return &out, nil
+}
+
+func (c *Client) UpdateEnvironment(ctx context.Context, orgId, envId string, params cac.EnvironmentInput) (*cac.EnvironmentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	env, err := c.environment(orgId, envId)
+	if err != nil {
+		return nil, err
+	}
+	env.Name = params.Name
+	env.Description = params.Description
+	c.envData[envId] = params.Data
+
+	out := *env
+	return &out, nil
+}
+
+func (c *Client) DestroyEnvironment(ctx context.Context, orgId, envId string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.environment(orgId, envId); err != nil {
+		return err
+	}
+	delete(c.envs, envId)
+	delete(c.envPolls, envId)
+	delete(c.envData, envId)
+	return nil
+}
+
+func (c *Client) ListOrgs(ctx context.Context) ([]cac.OrganizationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	orgs := []cac.OrganizationOutput{}
+	for _, org := range c.orgs {
+		orgs = append(orgs, *org)
+	}
+	return orgs, nil
+}
+
+func (c *Client) CreateOrg(ctx context.Context, orgId string, params cac.OrganizationInput) (*cac.OrganizationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	org := &cac.OrganizationOutput{
+		Id:             orgId,
+		Name:           params.Name,
+		BaaStatus:      params.BaaStatus,
+		AwsOu:          params.AwsOu,
+		ContactDetails: params.ContactDetails,
+	}
+	c.orgs[org.Id] = org
+
+	out := *org
+	return &out, nil
+}
+
+func (c *Client) FindOrg(ctx context.Context, orgId string) (*cac.OrganizationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	org, ok := c.orgs[orgId]
+	if !ok {
+		return nil, notFound("organization", orgId)
+	}
+	out := *org
+	return &out, nil
+}
+
+func (c *Client) ListAssetBundles(ctx context.Context, orgId, envId string) ([]cac.AssetBundle, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.environment(orgId, envId); err != nil {
+		return nil, err
+	}
+	if c.Bundles != nil {
+		return c.Bundles, nil
+	}
+	return DefaultBundles(), nil
+}
+
+func (c *Client) CreateAsset(ctx context.Context, orgId, envId string, params cac.AssetInput) (*cac.AssetOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	env, err := c.environment(orgId, envId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := normalize(params.AssetParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &asset{
+		output: cac.AssetOutput{
+			Id:           uuid.NewString(),
+			Asset:        params.Asset,
+			AssetVersion: params.AssetVersion,
+			ConnectsTo:   params.ConnectsTo,
+			CurrentAssetParameters: cac.AssetParametersOutput{
+				Id:   uuid.NewString(),
+				Data: data,
+			},
+			Environment: *env,
+			UserDefined: true,
+			Outputs:     &map[string]cac.AssetTerraformOutput{},
+		},
+	}
+	c.assets[a.output.Id] = a
+	c.startOperation(a, cac.OPERATIONTYPE_CREATE, cac.ASSETSTATUS_PENDING, cac.ASSETSTATUS_DEPLOYED)
+	c.settle(a)
+
+	return a.snapshot(), nil
+}
+
+func (c *Client) ListAssets(ctx context.Context, orgId, envId string) ([]cac.AssetOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.environment(orgId, envId); err != nil {
+		return nil, err
+	}
+
+	assets := []cac.AssetOutput{}
+	for _, a := range c.assets {
+		if a.output.Environment.Id == envId {
+			c.settle(a)
+			assets = append(assets, *a.snapshot())
+		}
+	}
+	return assets, nil
+}
+
+func (c *Client) DescribeAsset(ctx context.Context, orgId, envId, assetId string) (*cac.AssetOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, err := c.asset(orgId, envId, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.pendingPolls > 0 {
+		a.pendingPolls--
+	}
+	c.settle(a)
+
+	return a.snapshot(), nil
+}
+
+func (c *Client) DestroyAsset(ctx context.Context, orgId, envId, assetId string) (*cac.AssetOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, err := c.asset(orgId, envId, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	c.startOperation(a, cac.OPERATIONTYPE_DESTROY, cac.ASSETSTATUS_DESTROYING, cac.ASSETSTATUS_DESTROYED)
+	c.settle(a)
+
+	return a.snapshot(), nil
+}
+
+func (c *Client) UpdateAsset(ctx context.Context, assetId string, envId string, orgId string, params cac.AssetInput) (*cac.AssetOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, err := c.asset(orgId, envId, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := normalize(params.AssetParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	a.output.Asset = params.Asset
+	a.output.AssetVersion = params.AssetVersion
+	a.output.ConnectsTo = params.ConnectsTo
+	a.output.CurrentAssetParameters.Data = data
+	a.output.CurrentAssetParameters.Iteration++
+	c.startOperation(a, cac.OPERATIONTYPE_UPDATE, cac.ASSETSTATUS_DEPLOYING, cac.ASSETSTATUS_DEPLOYED)
+	c.settle(a)
+
+	return a.snapshot(), nil
+}
+
+// DescribeOperation - polling an operation advances its asset the same way DescribeAsset does
+func (c *Client) DescribeOperation(ctx context.Context, orgId, operationId string) (*client.Operation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, op := range c.operations {
+		if op.Id != operationId || op.OrganizationId != orgId {
+			continue
+		}
+		if a, ok := c.assets[op.AssetId]; ok && c.operation(a) == op {
+			if a.pendingPolls > 0 {
+				a.pendingPolls--
+			}
+			c.settle(a)
+		}
+		out := *op
+		return &out, nil
+	}
+	return nil, notFound("operation", operationId)
+}
+
+func (c *Client) ListOperationsByAsset(ctx context.Context, orgId, assetId string) ([]client.Operation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ops := []client.Operation{}
+	for _, op := range c.operations {
+		if op.OrganizationId != orgId || op.AssetId != assetId {
+			continue
+		}
+		if c.unlisted[op.Id] > 0 {
+			c.unlisted[op.Id]--
+			continue
+		}
+		ops = append(ops, *op)
+	}
+	if c.NewestOperationsFirst {
+		for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+			ops[i], ops[j] = ops[j], ops[i]
+		}
+	}
+	return ops, nil
+}
+
+func (c *Client) CreateConnection(ctx context.Context, orgId, envId, assetId string, params cac.ConnectionInput) (*cac.ConnectionOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	incoming, err := c.asset(orgId, envId, assetId)
+	if err != nil {
+		return nil, err
+	}
+	outgoing, ok := c.assets[params.OutgoingAssetId]
+	if !ok {
+		return nil, notFound("asset", params.OutgoingAssetId)
+	}
+
+	conn := &connection{
+		assetId: assetId,
+		output: cac.ConnectionOutput{
+			Id:                      uuid.NewString(),
+			IncomingConnectionAsset: incoming.snapshot(),
+			OutgoingConnectionAsset: outgoing.snapshot(),
+			Status:                  cac.CONNECTIONSTATUS_PENDING,
+		},
+	}
+	c.connections[conn.output.Id] = conn
+
+	out := conn.output
+	return &out, nil
+}
+
+func (c *Client) DestroyConnection(ctx context.Context, orgId, envId, assetId, connectionId string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, err := c.connection(orgId, envId, assetId, connectionId)
+	if err != nil {
+		return err
+	}
+	conn.output.Status = cac.CONNECTIONSTATUS_DELETED
+	return nil
+}
+
+func (c *Client) GetConnection(ctx context.Context, orgId, envId, assetId, connectionId string) (*cac.ConnectionOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, err := c.connection(orgId, envId, assetId, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	if conn.output.Status == cac.CONNECTIONSTATUS_PENDING {
+		conn.output.Status = cac.CONNECTIONSTATUS_ACTIVE
+	}
+
+	out := conn.output
+	return &out, nil
+}
+
+func (c *Client) environment(orgId, envId string) (*cac.EnvironmentOutput, error) {
+	env, ok := c.envs[envId]
+	if !ok || env.Organization.Id != orgId {
+		return nil, notFound("environment", envId)
+	}
+	return env, nil
+}
+
+// provision - give the environment its aws account
+func (c *Client) provision(env *cac.EnvironmentOutput) {
+	if env.AwsAccountId != nil {
+		return
+	}
+	accountId := fmt.Sprintf("%012d", len(c.envs))
+	env.AwsAccountId = &accountId
+	delete(c.envPolls, env.Id)
+}
+
+func (c *Client) asset(orgId, envId, assetId string) (*asset, error) {
+	a, ok := c.assets[assetId]
+	if !ok || a.output.Environment.Id != envId || a.output.Environment.Organization.Id != orgId {
+		return nil, notFound("asset", assetId)
+	}
+	return a, nil
+}
+
+func (c *Client) connection(orgId, envId, assetId, connectionId string) (*connection, error) {
+	if _, err := c.asset(orgId, envId, assetId); err != nil {
+		return nil, err
+	}
+	conn, ok := c.connections[connectionId]
+	if !ok || conn.assetId != assetId {
+		return nil, notFound("connection", connectionId)
+	}
+	return conn, nil
+}
+
+// startOperation - record an operation on the asset, moving it to status until it settles
+func (c *Client) startOperation(a *asset, opType cac.OperationType, status, settled cac.AssetStatus) {
+	if !c.StaleStatus || a.output.Status == "" {
+		a.output.Status = status
+	}
+	a.pending = true
+	a.pendingPolls = c.PollsToSettle
+	a.changedAt = time.Now()
+	a.settled = settled
+
+	now := time.Now().UTC()
+	op := &client.Operation{
+		OperationOutput: cac.OperationOutput{
+			Id:              uuid.NewString(),
+			EnvironmentId:   a.output.Environment.Id,
+			OrganizationId:  a.output.Environment.Organization.Id,
+			UserId:          "fake-user",
+			AssetName:       a.output.Asset,
+			AssetId:         a.output.Id,
+			AssetVersion:    a.output.AssetVersion,
+			AssetParameters: a.output.CurrentAssetParameters.Data,
+		},
+		OperationDetails: client.OperationDetails{CreatedAt: &now, UpdatedAt: &now},
+	}
+	op.SetOperationType(opType)
+	op.SetStatus(cac.OPERATIONSTATUS_IN_PROGRESS)
+	c.operations = append(c.operations, op)
+	if c.UnlistedOperationPolls > 0 {
+		c.unlisted[op.Id] = c.UnlistedOperationPolls
+	}
+
+	opId := op.Id
+	a.output.OperationId = &opId
+}
+
+// settle - move an asset to its settled status once it has been polled enough, or once
+// SettleAfter has passed
+func (c *Client) settle(a *asset) {
+	if !a.pending {
+		return
+	}
+	if c.SettleAfter > 0 && time.Since(a.changedAt) < c.SettleAfter {
+		return
+	}
+	if c.SettleAfter == 0 && a.pendingPolls > 0 {
+		return
+	}
+
+	a.pending = false
+	a.output.Status = a.settled
+	if a.settled == cac.ASSETSTATUS_DEPLOYED {
+		outputs := c.Outputs
+		if outputs == nil {
+			outputs = DefaultOutputs
+		}
+		out := outputs(a.output)
+		a.output.Outputs = &out
+	}
+
+	c.finishOperation(a, cac.OPERATIONSTATUS_COMPLETE)
+}
+
+// finishOperation - move the operation last started on the asset to a terminal status
+func (c *Client) finishOperation(a *asset, status cac.OperationStatus) {
+	if op := c.operation(a); op != nil && op.GetStatus() == cac.OPERATIONSTATUS_IN_PROGRESS {
+		now := time.Now().UTC()
+		op.SetStatus(status)
+		op.UpdatedAt = &now
+	}
+}
+
+// operation - the operation last started on the asset
+func (c *Client) operation(a *asset) *client.Operation {
+	if a.output.OperationId == nil {
+		return nil
+	}
+	for _, op := range c.operations {
+		if op.Id == *a.output.OperationId {
+			return op
+		}
+	}
+	return nil
+}
+
+func (a *asset) snapshot() *cac.AssetOutput {
+	out := a.output
+
+	data, _ := normalize(a.output.CurrentAssetParameters.Data)
+	out.CurrentAssetParameters.Data = data
+
+	outputs := map[string]cac.AssetTerraformOutput{}
+	if a.output.Outputs != nil {
+		for k, v := range *a.output.Outputs {
+			outputs[k] = v
+		}
+	}
+	out.Outputs = &outputs
+
+	return &out
+}
+
+// normalize - round trip parameters through json so they come back the way the api would decode them
+func normalize(params map[string]interface{}) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	bts, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bts, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// assetType - extract the type out of an asset identifier, e.g. rds for aws__rds__latest
+func assetType(identifier string) string {
+	parts := strings.Split(identifier, client.DELIMITER)
+	if len(parts) != 3 {
+		return identifier
+	}
+	return parts[1]
+}
+
+// notFound - shaped like the error the real client returns for a 404
+func notFound(kind, id string) error {
+	return &client.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("%s %s not found", kind, id),
+		Err:        ErrNotFound,
+	}
+}
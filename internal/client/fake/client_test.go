@@ -0,0 +1,67 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+func TestAssetStatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	org := c.AddOrg("org")
+	env := c.AddEnvironment(org.Id, "env")
+
+	created, err := c.CreateAsset(ctx, org.Id, env.Id, cac.AssetInput{
+		Asset:           client.CompileAsset("aws", "secret_manager", "latest"),
+		AssetVersion:    "latest",
+		AssetParameters: map[string]interface{}{"name": "secret"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_PENDING, created.Status)
+	assert.Empty(t, *created.Outputs)
+
+	statuses := []cac.AssetStatus{}
+	for i := 0; i < c.PollsToSettle; i++ {
+		asset, err := c.DescribeAsset(ctx, org.Id, env.Id, created.Id)
+		assert.Nil(t, err)
+		statuses = append(statuses, asset.Status)
+	}
+	assert.Equal(t, []cac.AssetStatus{cac.ASSETSTATUS_PENDING, cac.ASSETSTATUS_DEPLOYED}, statuses)
+
+	_, err = c.DestroyAsset(ctx, org.Id, env.Id, created.Id)
+	assert.Nil(t, err)
+	for i := 0; i < c.PollsToSettle; i++ {
+		_, _ = c.DescribeAsset(ctx, org.Id, env.Id, created.Id)
+	}
+	asset, err := c.DescribeAsset(ctx, org.Id, env.Id, created.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+
+	ops, err := c.ListOperationsByAsset(ctx, org.Id, created.Id)
+	assert.Nil(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, cac.OPERATIONTYPE_CREATE, ops[0].GetOperationType())
+	assert.Equal(t, cac.OPERATIONTYPE_DESTROY, ops[1].GetOperationType())
+	assert.Equal(t, cac.OPERATIONSTATUS_COMPLETE, ops[1].GetStatus())
+}
+
+func TestUnknownIdsAreNotFound(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	org := c.AddOrg("org")
+	env := c.AddEnvironment(org.Id, "env")
+
+	_, err := c.DescribeAsset(ctx, org.Id, env.Id, "missing")
+	assert.NotNil(t, err)
+
+	_, err = c.DescribeEnvironment(ctx, org.Id, "missing")
+	assert.NotNil(t, err)
+
+	_, err = c.FindOrg(ctx, "missing")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,88 @@
+package fake
+
+import (
+	"fmt"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+// bundleTypes - asset types backing the typed aptible_aws_* resources
+var bundleTypes = []string{
+	"vpc",
+	"acm_certificate",
+	"acm_certificate_waiter",
+	"rds",
+	"elasticache_redis",
+	"secret_manager",
+	"ecs_web_service",
+	"ecs_compute_service",
+}
+
+// DefaultBundles - the aws bundles every fake environment is allowed to use
+func DefaultBundles() []cac.AssetBundle {
+	bundles := []cac.AssetBundle{}
+	for _, t := range bundleTypes {
+		bundles = append(bundles, cac.AssetBundle{
+			Identifier: client.CompileAsset("aws", t, "latest"),
+			Name:       t,
+			Types:      []string{"latest"},
+			Actions:    map[string]cac.AssetAction{},
+		})
+	}
+	return bundles
+}
+
+// DefaultOutputs - plausible outputs for the aws bundles, keyed the way the backend reports them
+func DefaultOutputs(asset cac.AssetOutput) map[string]cac.AssetTerraformOutput {
+	arn := func(service, resource string) cac.AssetTerraformOutput {
+		return stringOutput(fmt.Sprintf("arn:aws:%s:us-east-1:000000000000:%s/%s", service, resource, asset.Id))
+	}
+
+	switch assetType(asset.Asset) {
+	case "acm_certificate":
+		fqdn, _ := asset.CurrentAssetParameters.Data["fqdn"].(string)
+		return map[string]cac.AssetTerraformOutput{
+			"acm_certificate_arn": arn("acm", "certificate"),
+			"dns_validation_records": {
+				Data: []interface{}{
+					map[string]interface{}{
+						"domain_name":           fqdn,
+						"resource_record_name":  "_validation." + fqdn,
+						"resource_record_type":  "CNAME",
+						"resource_record_value": "_validation.acm-validations.aws",
+					},
+				},
+			},
+		}
+	case "rds":
+		return map[string]cac.AssetTerraformOutput{
+			"uri_secret_arn":          arn("secretsmanager", "secret"),
+			"rds_secrets_kms_key_arn": arn("kms", "key"),
+			"db_identifier":           stringOutput("db-" + asset.Id),
+		}
+	case "elasticache_redis":
+		return map[string]cac.AssetTerraformOutput{
+			"elasticache_token_secret_arn":  arn("secretsmanager", "secret"),
+			"elasticache_token_kms_key_arn": arn("kms", "key"),
+			"elasticache_arn":               arn("elasticache", "replicationgroup"),
+			"elasticache_cluster_id":        stringOutput("redis-" + asset.Id),
+		}
+	case "secret_manager":
+		return map[string]cac.AssetTerraformOutput{
+			"secret_arn": arn("secretsmanager", "secret"),
+			"kms_arn":    arn("kms", "key"),
+		}
+	case "ecs_web_service":
+		return map[string]cac.AssetTerraformOutput{
+			"load_balancer_url": stringOutput(fmt.Sprintf("lb-%s.us-east-1.elb.amazonaws.com", asset.Id)),
+		}
+	}
+
+	return map[string]cac.AssetTerraformOutput{}
+}
+
+func stringOutput(value string) cac.AssetTerraformOutput {
+	return cac.AssetTerraformOutput{Data: value}
+}
@@ -0,0 +1,284 @@
+/*
+Package fakeapi is a local stand-in for the Cloud API.
+
+It serves the subset of the cloud-api-clients OpenAPI surface this provider
+calls (assets, environments, organizations, operations and connections) on
+top of the in-memory fake client, so the provider can be pointed at it with
+`host = server.URL` and run end to end without network access.
+*/
+package fakeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/google/uuid"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fake"
+)
+
+const apiPrefix = "/api/v1/organizations"
+
+var errInvalidBody = errors.New("invalid request body")
+
+type Server struct {
+	// Backend - in-memory state shared by every request, use it to seed and inspect data
+	Backend *fake.Client
+	// Token - when set, requests must carry it as a bearer token
+	Token string
+}
+
+// NewServer - generate a server on top of backend, a fresh fake client when nil
+func NewServer(backend *fake.Client) *Server {
+	if backend == nil {
+		backend = fake.NewClient()
+	}
+	return &Server{Backend: backend}
+}
+
+// Start - serve on a local port, the caller must Close the returned server
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Request-Id", uuid.NewString())
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid or missing token")
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// /api/v1/organizations/{org}/environments/{env}/assets/{asset}/connections/{conn}
+	segments := strings.FieldsFunc(strings.TrimPrefix(r.URL.Path, apiPrefix), func(r rune) bool { return r == '/' })
+	route := routeFor(segments)
+	handler, ok := routes[route+" "+r.Method]
+	if !ok {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	status, body, err := handler(s, r, params(segments))
+	switch {
+	case errors.Is(err, fake.ErrNotFound):
+		writeError(w, http.StatusNotFound, message(err))
+	case errors.Is(err, errInvalidBody):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeJSON(w, status, body)
+	}
+}
+
+type handlerFunc func(s *Server, r *http.Request, p map[string]string) (int, interface{}, error)
+
+// routeFor - replace the ids in a path with placeholders, e.g. org/environments/env
+func routeFor(segments []string) string {
+	route := []string{}
+	for idx, segment := range segments {
+		if idx%2 == 0 {
+			route = append(route, "{id}")
+		} else {
+			route = append(route, segment)
+		}
+	}
+	return strings.Join(route, "/")
+}
+
+// params - map each collection in the path to the id that follows it
+func params(segments []string) map[string]string {
+	p := map[string]string{}
+	collection := "organizations"
+	for idx, segment := range segments {
+		if idx%2 == 0 {
+			p[collection] = segment
+		} else {
+			collection = segment
+		}
+	}
+	return p
+}
+
+var routes = map[string]handlerFunc{
+	" GET":     listOrgs,
+	"{id} GET": findOrg,
+	"{id} PUT": updateOrg,
+
+	"{id}/environments GET":         listEnvironments,
+	"{id}/environments POST":        createEnvironment,
+	"{id}/environments/{id} GET":    describeEnvironment,
+	"{id}/environments/{id} PUT":    updateEnvironment,
+	"{id}/environments/{id} DELETE": destroyEnvironment,
+
+	"{id}/environments/{id}/asset_bundles GET":  listAssetBundles,
+	"{id}/environments/{id}/assets GET":         listAssets,
+	"{id}/environments/{id}/assets POST":        createAsset,
+	"{id}/environments/{id}/assets/{id} GET":    describeAsset,
+	"{id}/environments/{id}/assets/{id} PUT":    updateAsset,
+	"{id}/environments/{id}/assets/{id} DELETE": destroyAsset,
+
+	"{id}/environments/{id}/assets/{id}/connections POST":        createConnection,
+	"{id}/environments/{id}/assets/{id}/connections/{id} GET":    getConnection,
+	"{id}/environments/{id}/assets/{id}/connections/{id} DELETE": destroyConnection,
+
+	"{id}/operations GET":      listOperations,
+	"{id}/operations/{id} GET": describeOperation,
+}
+
+func listOrgs(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	orgs, err := s.Backend.ListOrgs(r.Context())
+	return http.StatusOK, orgs, err
+}
+
+func findOrg(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	org, err := s.Backend.FindOrg(r.Context(), p["organizations"])
+	return http.StatusOK, org, err
+}
+
+func updateOrg(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	var input cac.OrganizationInput
+	if err := decode(r, &input); err != nil {
+		return 0, nil, err
+	}
+	org, err := s.Backend.CreateOrg(r.Context(), p["organizations"], input)
+	return http.StatusOK, org, err
+}
+
+func listEnvironments(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	envs, err := s.Backend.ListEnvironments(r.Context(), p["organizations"])
+	return http.StatusOK, envs, err
+}
+
+func createEnvironment(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	var input cac.EnvironmentInput
+	if err := decode(r, &input); err != nil {
+		return 0, nil, err
+	}
+	env, err := s.Backend.CreateEnvironment(r.Context(), p["organizations"], input)
+	return http.StatusCreated, env, err
+}
+
+func describeEnvironment(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	env, err := s.Backend.DescribeEnvironment(r.Context(), p["organizations"], p["environments"])
+	return http.StatusOK, env, err
+}
+
+func updateEnvironment(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	var input cac.EnvironmentInput
+	if err := decode(r, &input); err != nil {
+		return 0, nil, err
+	}
+	env, err := s.Backend.UpdateEnvironment(r.Context(), p["organizations"], p["environments"], input)
+	return http.StatusOK, env, err
+}
+
+func destroyEnvironment(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	err := s.Backend.DestroyEnvironment(r.Context(), p["organizations"], p["environments"])
+	return http.StatusOK, map[string]interface{}{}, err
+}
+
+func listAssetBundles(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	bundles, err := s.Backend.ListAssetBundles(r.Context(), p["organizations"], p["environments"])
+	return http.StatusOK, bundles, err
+}
+
+func listAssets(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	assets, err := s.Backend.ListAssets(r.Context(), p["organizations"], p["environments"])
+	return http.StatusOK, assets, err
+}
+
+func createAsset(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	var input cac.AssetInput
+	if err := decode(r, &input); err != nil {
+		return 0, nil, err
+	}
+	asset, err := s.Backend.CreateAsset(r.Context(), p["organizations"], p["environments"], input)
+	return http.StatusCreated, asset, err
+}
+
+func describeAsset(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	asset, err := s.Backend.DescribeAsset(r.Context(), p["organizations"], p["environments"], p["assets"])
+	return http.StatusOK, asset, err
+}
+
+func updateAsset(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	var input cac.AssetInput
+	if err := decode(r, &input); err != nil {
+		return 0, nil, err
+	}
+	asset, err := s.Backend.UpdateAsset(r.Context(), p["assets"], p["environments"], p["organizations"], input)
+	return http.StatusOK, asset, err
+}
+
+func destroyAsset(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	asset, err := s.Backend.DestroyAsset(r.Context(), p["organizations"], p["environments"], p["assets"])
+	return http.StatusOK, asset, err
+}
+
+func createConnection(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	var input cac.ConnectionInput
+	if err := decode(r, &input); err != nil {
+		return 0, nil, err
+	}
+	conn, err := s.Backend.CreateConnection(r.Context(), p["organizations"], p["environments"], p["assets"], input)
+	return http.StatusCreated, conn, err
+}
+
+func getConnection(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	conn, err := s.Backend.GetConnection(r.Context(), p["organizations"], p["environments"], p["assets"], p["connections"])
+	return http.StatusOK, conn, err
+}
+
+func destroyConnection(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	err := s.Backend.DestroyConnection(r.Context(), p["organizations"], p["environments"], p["assets"], p["connections"])
+	return http.StatusOK, map[string]interface{}{}, err
+}
+
+func listOperations(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	ops, err := s.Backend.ListOperationsByAsset(r.Context(), p["organizations"], r.URL.Query().Get("asset_id"))
+	return http.StatusOK, ops, err
+}
+
+func describeOperation(s *Server, r *http.Request, p map[string]string) (int, interface{}, error) {
+	op, err := s.Backend.DescribeOperation(r.Context(), p["organizations"], p["operations"])
+	return http.StatusOK, op, err
+}
+
+func decode(r *http.Request, target interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidBody, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// message - the message of an error returned by the fake client, without the status it is shaped with
+func message(err error) string {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Message
+	}
+	return err.Error()
+}
+
+// writeError - errors are shaped like the api's, {"detail": "..."}
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
@@ -0,0 +1,88 @@
+package fakeapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+func setup(t *testing.T) (client.CloudClient, *Server, cac.EnvironmentOutput) {
+	t.Helper()
+	server := NewServer(nil)
+	server.Token = "token"
+	org := server.Backend.AddOrg("org")
+	env := server.Backend.AddEnvironment(org.Id, "env")
+
+	ts := server.Start()
+	t.Cleanup(ts.Close)
+
+	return client.NewClient(false, ts.URL, "token"), server, env
+}
+
+func TestAssetLifecycle(t *testing.T) {
+	c, _, env := setup(t)
+	ctx := context.Background()
+	orgId := env.Organization.Id
+
+	created, err := c.CreateAsset(ctx, orgId, env.Id, cac.AssetInput{
+		Asset:           client.CompileAsset("aws", "vpc", "latest"),
+		AssetVersion:    "latest",
+		AssetParameters: map[string]interface{}{"name": "network"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_PENDING, created.Status)
+
+	var asset *cac.AssetOutput
+	for i := 0; i < 5; i++ {
+		asset, err = c.DescribeAsset(ctx, orgId, env.Id, created.Id)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, cac.ASSETSTATUS_DEPLOYED, asset.Status)
+	assert.Equal(t, "network", asset.CurrentAssetParameters.Data["name"])
+
+	ops, err := c.ListOperationsByAsset(ctx, orgId, created.Id)
+	assert.Nil(t, err)
+	assert.Len(t, ops, 1)
+
+	destroying, err := c.DestroyAsset(ctx, orgId, env.Id, created.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYING, destroying.Status)
+	assert.NotNil(t, destroying.OperationId)
+
+	var op *client.Operation
+	for i := 0; i < 5; i++ {
+		op, err = c.DescribeOperation(ctx, orgId, *destroying.OperationId)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, cac.OPERATIONTYPE_DESTROY, op.GetOperationType())
+	assert.Equal(t, cac.OPERATIONSTATUS_COMPLETE, op.GetStatus())
+
+	asset, err = c.DescribeAsset(ctx, orgId, env.Id, created.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
+
+func TestMissingAsset(t *testing.T) {
+	c, _, env := setup(t)
+
+	_, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, "missing")
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestRejectsInvalidToken(t *testing.T) {
+	_, server, env := setup(t)
+	ts := server.Start()
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+apiPrefix+"/"+env.Organization.Id, nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
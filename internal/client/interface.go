@@ -15,6 +15,7 @@ type CloudClient interface {
 	ListEnvironments(ctx context.Context, orgId string) ([]cac.EnvironmentOutput, error)
 	DescribeEnvironment(ctx context.Context, orgId, envId string) (*cac.EnvironmentOutput, error)
 	CreateEnvironment(ctx context.Context, orgId string, params cac.EnvironmentInput) (*cac.EnvironmentOutput, error)
+	UpdateEnvironment(ctx context.Context, orgId, envId string, params cac.EnvironmentInput) (*cac.EnvironmentOutput, error)
 	DestroyEnvironment(ctx context.Context, orgId, envId string) error
 
 	ListOrgs(ctx context.Context) ([]cac.OrganizationOutput, error)
@@ -25,10 +26,11 @@ type CloudClient interface {
 	CreateAsset(ctx context.Context, orgId, envId string, params cac.AssetInput) (*cac.AssetOutput, error)
 	ListAssets(ctx context.Context, orgId, envId string) ([]cac.AssetOutput, error)
 	DescribeAsset(ctx context.Context, orgId, envId, assetId string) (*cac.AssetOutput, error)
-	DestroyAsset(ctx context.Context, orgId, envId, assetID string) error
+	DestroyAsset(ctx context.Context, orgId, envId, assetID string) (*cac.AssetOutput, error)
 	UpdateAsset(ctx context.Context, assetId string, envId string, orgId string, params cac.AssetInput) (*cac.AssetOutput, error)
 
-	ListOperationsByAsset(ctx context.Context, orgId, assetId string) ([]cac.OperationOutput, error)
+	ListOperationsByAsset(ctx context.Context, orgId, assetId string) ([]Operation, error)
+	DescribeOperation(ctx context.Context, orgId, operationId string) (*Operation, error)
 
 	CreateConnection(ctx context.Context, orgId, envId, assetId string, params cac.ConnectionInput) (*cac.ConnectionOutput, error)
 	DestroyConnection(ctx context.Context, orgId, envId, assetId, connectionId string) error
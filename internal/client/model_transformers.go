@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/exp/maps"
 
@@ -41,6 +41,16 @@ func CompileAsset(provider, name, ver string) string {
 	)
 }
 
+// SplitAsset - the provider, name and version of an asset identifier like aws__vpc__latest, the inverse
+// of CompileAsset. Missing parts are left empty.
+func SplitAsset(identifier string) (string, string, string) {
+	parts := strings.SplitN(identifier, DELIMITER, 3)
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	return parts[0], parts[1], parts[2]
+}
+
 /**
 name="map[Null:false Unknown:false Value:my_null]" tf_resource_type=aptible_null_simple tf_rpc=ApplyResourceChange asset_type="map[Null:false Unknown:false Value:simple]" @caller=/Users/madhu/work/terraform-provider-aptible-iaas/internal/client/model_transformers.go:40 @module=aptible_iaas asset_version="map[Null:false Unknown:false Value:latest]" id="map[Null:false Unknown:true Value:]" organization_id="map[Null:false Unknown:false Value:2253ae98-d65a-4180-aceb-8419b7416677]" status="map[Null:false Unknown:true Value:]" tf_provider_addr=aptible.com/aptible/aptible-iaas tf_req_id=e6b2222c-24d2-84fe-2a29-24384c2cead0 asset_platform="map[Null:false Unknown:false Value:null]" environment_id="map[Null:false Unknown:false Value:238930f4-0750-4f55-b43c-e1a11c437e23]" timestamp=2022-09-21T18:31:09.519-0400
 2022-09-21T18:31:09.519-0400 [INFO]  provider.terraform-provider-aptible-iaas_0.0.0+local_darwin_arm64: Using these asset para
@@ -50,29 +60,21 @@ name="map[Null:false Unknown:false Value:my_null]" tf_resource_type=aptible_null
 func PopulateClientAssetInputForCreate(ctx context.Context, input []byte, assetName, cloud, version string) (*cloud_api_client.AssetInput, error) {
 	allOutput := make(map[string]interface{})
 	assetParameters := make(map[string]interface{})
-	if err := json.Unmarshal(input, &allOutput); err != nil {
-		return nil, err
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &allOutput); err != nil {
+			return nil, fmt.Errorf("asset parameters must be a json object: %w", err)
+		}
 	}
 
-	tflog.Trace(ctx, "Sending following data to backend", allOutput)
 	for k, v := range allOutput {
-		for _, excludedKey := range TOP_LEVEL_KEYS {
-			if excludedKey == k {
-				continue
-			}
-			switch value := v.(type) {
-			// TODO - look at multiple
-			case types.String:
-				assetParameters[k] = value.Value
-			case types.Bool:
-				assetParameters[k] = value.Value
-			default:
-				tflog.Warn(ctx, fmt.Sprintf("unexpected type (%s) on key (%s)", value, k))
-			}
+		if isTopLevelKey(k) {
+			continue
 		}
+		assetParameters[k] = v
 	}
 
-	tflog.Info(ctx, "Using these asset parameters", assetParameters)
+	// only the keys, values may be secrets
+	tflog.Info(ctx, "Using these asset parameters", map[string]interface{}{"keys": maps.Keys(assetParameters)})
 
 	return &cloud_api_client.AssetInput{
 		Asset:           CompileAsset(cloud, assetName, version),
@@ -81,14 +83,11 @@ func PopulateClientAssetInputForCreate(ctx context.Context, input []byte, assetN
 	}, nil
 }
 
-// take a set of inputs, describe the asset, and safely interpolate for recreate
-func PopulateClientAssetInputForUpdate(ctx context.Context, cloudApiAssetState *cloud_api_client.AssetOutput, input []byte, assetName, cloud, version string) (*cloud_api_client.AssetInput, error) {
-	creationInput, err := PopulateClientAssetInputForCreate(ctx, input, assetName, cloud, version)
-	if err != nil {
-		return nil, err
+func isTopLevelKey(key string) bool {
+	for _, excludedKey := range TOP_LEVEL_KEYS {
+		if excludedKey == key {
+			return true
+		}
 	}
-
-	maps.Copy(creationInput.AssetParameters, cloudApiAssetState.CurrentAssetParameters.Data)
-
-	return creationInput, nil
+	return false
 }
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateClientAssetInputForCreate(t *testing.T) {
+	input, err := PopulateClientAssetInputForCreate(
+		context.Background(),
+		[]byte(`{"name": "db", "port": 5432, "public": false, "tags": {"team": "core"}, "id": "ignored"}`),
+		"rds", "aws", "v1",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "aws__rds__v1", input.Asset)
+	assert.Equal(t, "v1", input.AssetVersion)
+	assert.Equal(t, map[string]interface{}{
+		"name":   "db",
+		"port":   float64(5432),
+		"public": false,
+		"tags":   map[string]interface{}{"team": "core"},
+	}, input.AssetParameters)
+}
+
+func TestPopulateClientAssetInputForCreateRejectsInvalidJSON(t *testing.T) {
+	_, err := PopulateClientAssetInputForCreate(context.Background(), []byte(`["name"]`), "rds", "aws", "v1")
+	assert.Error(t, err)
+}
+
+func TestSplitAsset(t *testing.T) {
+	provider, name, version := SplitAsset(CompileAsset("aws", "ecs_web_service", "v2"))
+	assert.Equal(t, []string{"aws", "ecs_web_service", "v2"}, []string{provider, name, version})
+
+	provider, name, version = SplitAsset("aws__rds")
+	assert.Equal(t, []string{"aws", "rds", ""}, []string{provider, name, version})
+}
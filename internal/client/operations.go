@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+)
+
+// Operation - an operation as returned by the api, cac.OperationOutput does not model when it ran or
+// why it failed yet so those are decoded from the raw response into OperationDetails
+type Operation struct {
+	cac.OperationOutput
+	OperationDetails
+}
+
+// OperationDetails - fields of an operation the generated client drops, each is empty when the api
+// did not send it
+type OperationDetails struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// Error - why the operation failed
+	Error string `json:"error,omitempty"`
+	// Logs - output of the terraform run or action that failed
+	Logs string `json:"logs,omitempty"`
+}
+
+// operationData - the last update reported by the worker running the operation, one of
+// cac.OperationFailure, cac.OperationAssetUpdate or cac.OperationActionUpdate
+type operationData struct {
+	Error                  string        `json:"error"`
+	TerraformPlanFailures  string        `json:"terraform_plan_failures"`
+	TerraformApplyFailures string        `json:"terraform_apply_failures"`
+	ActionErrors           string        `json:"action_errors"`
+	TerraformInit          *terraformRun `json:"terraform_init"`
+	TerraformPlan          *terraformRun `json:"terraform_plan"`
+	TerraformApply         *terraformRun `json:"terraform_apply"`
+}
+
+type terraformRun struct {
+	Stderr  interface{} `json:"terraform_stderr"`
+	Success bool        `json:"terraform_success"`
+}
+
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &o.OperationOutput); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &o.OperationDetails); err != nil {
+		return err
+	}
+
+	var update struct {
+		Data *operationData `json:"data"`
+	}
+	if err := json.Unmarshal(data, &update); err != nil || update.Data == nil {
+		return nil
+	}
+	if o.Error == "" {
+		o.Error = firstNonEmpty(
+			update.Data.Error,
+			update.Data.TerraformApplyFailures,
+			update.Data.TerraformPlanFailures,
+			update.Data.ActionErrors,
+		)
+	}
+	if o.Logs == "" {
+		for _, run := range []*terraformRun{update.Data.TerraformApply, update.Data.TerraformPlan, update.Data.TerraformInit} {
+			if run != nil && !run.Success && run.Stderr != nil {
+				o.Logs = stringify(run.Stderr)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (o Operation) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{}
+	for _, part := range []interface{}{o.OperationOutput, o.OperationDetails} {
+		bts, err := json.Marshal(part)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bts, &out); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(out)
+}
+
+// decodeBody - decode the response again, for the fields the generated client drops
+func decodeBody(r *http.Response, target interface{}) error {
+	if r == nil || r.Body == nil {
+		return fmt.Errorf("no response body")
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// stringify - terraform output is reported either as a string or as a list of lines
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		lines := []string{}
+		for _, line := range v {
+			lines = append(lines, fmt.Sprint(line))
+		}
+		return strings.Join(lines, "\n")
+	default:
+		bts, _ := json.Marshal(v)
+		return string(bts)
+	}
+}
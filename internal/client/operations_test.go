@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationDecodesDetails(t *testing.T) {
+	var op Operation
+	err := json.Unmarshal([]byte(`{
+		"id": "op-1",
+		"asset_id": "asset-1",
+		"operation_type": "UPDATE",
+		"status": "FAILED",
+		"created_at": "2022-10-12T15:02:24Z",
+		"updated_at": "2022-10-12T15:10:01Z",
+		"data": {
+			"terraform_init": {"terraform_return_code": 0, "terraform_success": true},
+			"terraform_apply": {
+				"terraform_return_code": 1,
+				"terraform_success": false,
+				"terraform_stderr": ["Error: creating RDS instance", "InvalidParameterCombination"]
+			},
+			"terraform_apply_failures": "apply failed"
+		}
+	}`), &op)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "op-1", op.Id)
+	assert.Equal(t, cac.OPERATIONTYPE_UPDATE, op.GetOperationType())
+	assert.Equal(t, cac.OPERATIONSTATUS_FAILED, op.GetStatus())
+	assert.Equal(t, "2022-10-12T15:02:24Z", op.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	assert.NotNil(t, op.UpdatedAt)
+	assert.Equal(t, "apply failed", op.Error)
+	assert.Equal(t, "Error: creating RDS instance\nInvalidParameterCombination", op.Logs)
+}
+
+func TestOperationWithoutDetails(t *testing.T) {
+	var op Operation
+	err := json.Unmarshal([]byte(`{"id": "op-1", "status": "COMPLETE"}`), &op)
+	assert.Nil(t, err)
+	assert.Nil(t, op.CreatedAt)
+	assert.Empty(t, op.Error)
+	assert.Empty(t, op.Logs)
+}
+
+func TestOperationRoundTrip(t *testing.T) {
+	op := Operation{
+		OperationOutput:  cac.OperationOutput{Id: "op-1"},
+		OperationDetails: OperationDetails{Error: "boom", Logs: "line"},
+	}
+	op.SetStatus(cac.OPERATIONSTATUS_FAILED)
+
+	bts, err := json.Marshal(op)
+	assert.Nil(t, err)
+
+	var decoded Operation
+	assert.Nil(t, json.Unmarshal(bts, &decoded))
+	assert.Equal(t, "op-1", decoded.Id)
+	assert.Equal(t, cac.OPERATIONSTATUS_FAILED, decoded.GetStatus())
+	assert.Equal(t, "boom", decoded.Error)
+	assert.Equal(t, "line", decoded.Logs)
+}
+
+func TestListOperationsByAssetKeepsDetails(t *testing.T) {
+	ts := respondWith(http.StatusOK, `[{"id": "op-1", "status": "FAILED", "data": {"error": "quota exceeded"}}]`)
+	defer ts.Close()
+
+	c := NewClient(false, ts.URL, "token")
+	ops, err := c.ListOperationsByAsset(context.Background(), "org", "asset")
+	assert.Nil(t, err)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, "quota exceeded", ops[0].Error)
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted - what sensitive values are replaced with in logs
+const Redacted = "***REDACTED***"
+
+// DefaultSensitiveFields - keys of request and response bodies that always hold secrets, whatever the asset
+var DefaultSensitiveFields = []string{
+	"secret_string",
+	"password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"private_key",
+	"secret_key",
+	"client_secret",
+}
+
+// sensitiveHeaders - headers carrying credentials
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "X-Api-Key"}
+
+// Redactor - masks credentials and secrets before they are logged
+type Redactor struct {
+	fields map[string]bool
+	values []string
+}
+
+// NewRedactor - mask the values of fields (matched case-insensitively, at any depth) and every
+// occurrence of values, e.g. the api token
+func NewRedactor(fields []string, values ...string) *Redactor {
+	r := &Redactor{fields: map[string]bool{}}
+	for _, field := range append(append([]string{}, DefaultSensitiveFields...), fields...) {
+		r.fields[strings.ToLower(field)] = true
+	}
+	for _, value := range values {
+		if value != "" {
+			r.values = append(r.values, value)
+		}
+	}
+	return r
+}
+
+// String - mask every known secret value in s
+func (r *Redactor) String(s string) string {
+	for _, value := range r.values {
+		s = strings.ReplaceAll(s, value, Redacted)
+	}
+	return s
+}
+
+// Headers - a copy of h with credentials masked, the scheme of an Authorization header is kept
+func (r *Redactor) Headers(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		values := out.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		out.Del(name)
+		for _, value := range values {
+			if scheme, _, found := strings.Cut(value, " "); found && name == "Authorization" {
+				out.Add(name, scheme+" "+Redacted)
+			} else {
+				out.Add(name, Redacted)
+			}
+		}
+	}
+	return out
+}
+
+// JSON - mask sensitive fields of a json document, anything that isn't json is only stripped of known
+// secret values
+func (r *Redactor) JSON(body []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return []byte(r.String(string(body)))
+	}
+	out, err := json.Marshal(r.Value(doc))
+	if err != nil {
+		return []byte(r.String(string(body)))
+	}
+	return []byte(r.String(string(out)))
+}
+
+// Value - mask sensitive fields of a decoded json value. Terraform outputs flagged sensitive by the
+// api, {"sensitive": true, "data": ...}, have their data masked too.
+func (r *Redactor) Value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		sensitive, _ := v["sensitive"].(bool)
+		for key, item := range v {
+			if item != nil && (r.fields[strings.ToLower(key)] || (sensitive && key == "data")) {
+				out[key] = Redacted
+			} else {
+				out[key] = r.Value(item)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for idx, item := range v {
+			out[idx] = r.Value(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
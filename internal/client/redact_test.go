@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactorJSON(t *testing.T) {
+	r := NewRedactor([]string{"ssh_key"}, "super-token")
+
+	out := string(r.JSON([]byte(`{
+		"asset_type": "secret",
+		"parameters": {"secret_string": "hunter2", "SSH_KEY": "AAAA", "name": "db"},
+		"outputs": {
+			"arn": {"name": "arn", "sensitive": false, "data": "arn:aws:x"},
+			"dsn": {"name": "dsn", "sensitive": true, "data": "postgres://u:p@host"}
+		},
+		"note": "signed with super-token"
+	}`)))
+
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "AAAA")
+	assert.NotContains(t, out, "postgres://u:p@host")
+	assert.NotContains(t, out, "super-token")
+	assert.Contains(t, out, `"name":"db"`)
+	assert.Contains(t, out, "arn:aws:x")
+	assert.Contains(t, out, Redacted)
+}
+
+func TestRedactorNotJSON(t *testing.T) {
+	r := NewRedactor(nil, "super-token")
+	assert.Equal(t, "token="+Redacted, string(r.JSON([]byte("token=super-token"))))
+}
+
+func TestRedactorHeaders(t *testing.T) {
+	r := NewRedactor(nil)
+	h := http.Header{}
+	h.Set("Authorization", "Bearer super-token")
+	h.Set("Content-Type", "application/json")
+
+	out := r.Headers(h)
+	assert.Equal(t, "Bearer "+Redacted, out.Get("Authorization"))
+	assert.Equal(t, "application/json", out.Get("Content-Type"))
+	// the original headers are untouched
+	assert.Equal(t, "Bearer super-token", h.Get("Authorization"))
+}
+
+func TestPrintResponseRedacts(t *testing.T) {
+	ts := respondWith(http.StatusOK, `{"id": "asset", "parameters": {"secret_string": "hunter2", "tls_key": "KEY"}}`)
+	defer ts.Close()
+
+	c := NewClient(true, ts.URL, "super-token", WithSensitiveFields("tls_key")).(*Client)
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer super-token")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	dump := c.PrintResponse(context.Background(), resp)
+	assert.NotContains(t, dump, "hunter2")
+	assert.NotContains(t, dump, "KEY")
+	assert.Contains(t, dump, "200 OK")
+	assert.Contains(t, dump, `"id":"asset"`)
+
+	// the body is still there for the generated client to decode
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), "hunter2"))
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxRetries - number of times a failed request is retried before the error is surfaced
+var DefaultMaxRetries = 5
+
+// DefaultMinRetryWait - backoff used for the first retry, doubled on every following attempt
+var DefaultMinRetryWait = 1 * time.Second
+
+// DefaultMaxRetryWait - upper bound on the backoff between two attempts, including Retry-After
+var DefaultMaxRetryWait = 30 * time.Second
+
+// RetryConfig - controls how the transport used by the Client retries transient failures
+type RetryConfig struct {
+	MaxRetries   int
+	MinRetryWait time.Duration
+	MaxRetryWait time.Duration
+}
+
+// DefaultRetryConfig - retry budget used when the provider block does not override it
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:   DefaultMaxRetries,
+		MinRetryWait: DefaultMinRetryWait,
+		MaxRetryWait: DefaultMaxRetryWait,
+	}
+}
+
+// RetryTransport - http.RoundTripper that retries requests failing with a 429 or a transient 5xx,
+// using exponential backoff with full jitter and honouring the Retry-After header
+type RetryTransport struct {
+	Base   http.RoundTripper
+	Config RetryConfig
+}
+
+// NewRetryTransport - wrap base (http.DefaultTransport when nil) with retries
+func NewRetryTransport(base http.RoundTripper, config RetryConfig) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{
+		Base:   base,
+		Config: config,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			// the previous attempt consumed the body, rewind it before sending again
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.Base.RoundTrip(attemptReq)
+		if attempt >= t.Config.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			// drain so the underlying connection can be reused for the next attempt
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepWithContext(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff - wait before the next attempt, capped at MaxRetryWait. A Retry-After sent by the
+// server takes precedence over the computed value
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.Config.MaxRetryWait {
+				return t.Config.MaxRetryWait
+			}
+			return wait
+		}
+	}
+
+	ceiling := float64(t.Config.MinRetryWait) * math.Pow(2, float64(attempt))
+	if ceiling > float64(t.Config.MaxRetryWait) {
+		ceiling = float64(t.Config.MaxRetryWait)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	// full jitter, see https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+// shouldRetry - decide whether a request can safely be sent again
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		// we cannot know whether a non idempotent request reached the server
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// the request was rejected before being processed, always safe to send again
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter - Retry-After is either a number of seconds or an HTTP date
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func sleepWithContext(req *http.Request, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryConfig = RetryConfig{
+	MaxRetries:   3,
+	MinRetryWait: time.Millisecond,
+	MaxRetryWait: 5 * time.Millisecond,
+}
+
+// failingServer - replies with status for the first failures requests, then 200 with the request body echoed back
+func failingServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryTransportRetriesIdempotentRequests(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		server, calls := failingServer(t, 2, status, nil)
+		c := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig)}
+
+		resp, err := c.Get(server.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	}
+}
+
+func TestRetryTransportGivesUpAfterBudget(t *testing.T) {
+	server, calls := failingServer(t, 10, http.StatusServiceUnavailable, nil)
+	c := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig)}
+
+	resp, err := c.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(testRetryConfig.MaxRetries+1), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransportDoesNotRetryPostOnServerError(t *testing.T) {
+	server, calls := failingServer(t, 1, http.StatusBadGateway, nil)
+	c := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig)}
+
+	resp, err := c.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransportReplaysBodyOnThrottledPost(t *testing.T) {
+	server, calls := failingServer(t, 1, http.StatusTooManyRequests, nil)
+	c := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig)}
+
+	resp, err := c.Post(server.URL, "application/json", strings.NewReader(`{"name":"vpc"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"name":"vpc"}`, string(body))
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	server, calls := failingServer(t, 1, http.StatusNotFound, nil)
+	c := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig)}
+
+	resp, err := c.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server, calls := failingServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}})
+	config := testRetryConfig
+	config.MaxRetryWait = 2 * time.Second
+	c := &http.Client{Transport: NewRetryTransport(nil, config)}
+
+	start := time.Now()
+	resp, err := c.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
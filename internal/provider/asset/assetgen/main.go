@@ -0,0 +1,154 @@
+// Command assetgen generates the ResourceModel, AssetSchema and the
+// planToAssetInput/assetOutputToPlan converters of an asset type from the schema
+// of its bundle, checked into the asset package as bundle.json.
+//
+// Run from an asset package by go generate:
+//
+//	//go:generate go run ../../assetgen
+//
+// bundle.json holds the bundle as ListAssetBundles returns it, whose
+// user_parameters are a json schema of the asset parameters, the outputs of the
+// bundle, which the api doesn't describe, and terraform specific settings keyed
+// by parameter or output:
+//
+//	{
+//	  "bundle": {"identifier": "aws__rds__latest", "user_parameters": {...}, ...},
+//	  "outputs": {"db_identifier": {"type": "string"}},
+//	  "terraform": {"rds_secrets_kms_key_arn": {"name": "secrets_kms_key_arn"}}
+//	}
+//
+// To refresh the bundles of the fixtures from an environment, run from the root
+// of the repository with APTIBLE_HOST and APTIBLE_TOKEN set:
+//
+//	go run ./internal/provider/asset/assetgen -refresh -organization-id ORG -environment-id ENV internal/provider/asset/aws/*/bundle.json
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("assetgen: ")
+
+	refresh := flag.Bool("refresh", false, "refresh the bundle of each fixture given as argument from ListAssetBundles")
+	orgId := flag.String("organization-id", os.Getenv("APTIBLE_ORGANIZATION_ID"), "organization of the environment to refresh from")
+	envId := flag.String("environment-id", os.Getenv("APTIBLE_ENVIRONMENT_ID"), "environment to refresh from")
+	input := flag.String("input", "bundle.json", "fixture to generate from")
+	output := flag.String("output", "models_gen.go", "file to generate")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+	flag.Parse()
+
+	if *refresh {
+		if err := refreshFixtures(context.Background(), *orgId, *envId, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *pkg == "" {
+		log.Fatal("-package is required outside of go generate")
+	}
+	src, err := generateFile(*pkg, *input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generateFile - the formatted go source generated from the fixture
+func generateFile(pkg, path string) ([]byte, error) {
+	f, err := readFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := newAssetSpec(pkg, filepath.Base(path), f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, spec); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: generated invalid go: %w\n%s", path, err, buf.String())
+	}
+	return src, nil
+}
+
+func readFixture(path string) (*fixture, error) {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &fixture{}
+	if err := json.Unmarshal(bts, f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// refreshFixtures - replace the bundle of each fixture with the one the environment allows, keeping the
+// outputs and terraform settings maintained by hand
+func refreshFixtures(ctx context.Context, orgId, envId string, paths []string) error {
+	if orgId == "" || envId == "" {
+		return fmt.Errorf("-organization-id and -environment-id are required to refresh")
+	}
+	host, token := os.Getenv("APTIBLE_HOST"), os.Getenv("APTIBLE_TOKEN")
+	if host == "" || token == "" {
+		return fmt.Errorf("APTIBLE_HOST and APTIBLE_TOKEN are required to refresh")
+	}
+
+	bundles, err := client.NewClient(client.DebugEnabled(), host, token).ListAssetBundles(ctx, orgId, envId)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		f, err := readFixture(path)
+		if err != nil {
+			return err
+		}
+		bundle, err := findBundle(bundles, f.Bundle.Identifier)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		f.Bundle = bundle
+
+		bts, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, append(bts, '\n'), 0644); err != nil {
+			return err
+		}
+		log.Printf("refreshed %s from %s", path, bundle.Identifier)
+	}
+	return nil
+}
+
+func findBundle(bundles []cac.AssetBundle, identifier string) (cac.AssetBundle, error) {
+	platform, assetType, _ := client.SplitAsset(identifier)
+	for _, bundle := range bundles {
+		if p, t, _ := client.SplitAsset(bundle.Identifier); p == platform && t == assetType {
+			return bundle, nil
+		}
+	}
+	return cac.AssetBundle{}, fmt.Errorf("the environment does not allow %s %s assets", platform, assetType)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var packageClause = regexp.MustCompile(`(?m)^package (\w+)$`)
+
+// the checked in models must be what go generate makes of the checked in bundles
+func TestGeneratedModelsUpToDate(t *testing.T) {
+	fixtures, err := filepath.Glob("../aws/*/bundle.json")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, fixtures)
+
+	for _, path := range fixtures {
+		generated := filepath.Join(filepath.Dir(path), "models_gen.go")
+		existing, err := os.ReadFile(generated)
+		assert.Nil(t, err, generated)
+		pkg := packageClause.FindSubmatch(existing)
+		if !assert.NotNil(t, pkg, generated) {
+			continue
+		}
+
+		src, err := generateFile(string(pkg[1]), path)
+		assert.Nil(t, err, path)
+		assert.Equal(t, string(existing), string(src), "%s is out of date, run make generate", generated)
+	}
+}
+
+func testFixture(params map[string]interface{}, required []string) *fixture {
+	return &fixture{
+		Bundle: cac.AssetBundle{
+			Identifier: "aws__widget__latest",
+			UserParameters: map[string]interface{}{
+				"type":       "object",
+				"required":   required,
+				"properties": params,
+			},
+		},
+		Outputs:   map[string]*property{},
+		Terraform: map[string]*override{},
+	}
+}
+
+func TestNewAssetSpec(t *testing.T) {
+	f := testFixture(map[string]interface{}{
+		"name":    map[string]interface{}{"type": "string", "minLength": 1, "description": "A name"},
+		"engine":  map[string]interface{}{"type": "string", "enum": []string{"a", "b"}},
+		"port":    map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 10},
+		"public":  map[string]interface{}{"type": "boolean", "default": false},
+		"tags":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		"token":   map[string]interface{}{"type": "string", "writeOnly": true},
+		"host":    map[string]interface{}{"type": "string", "format": "hostname"},
+		"subpart": map[string]interface{}{"type": "string"},
+	}, []string{"name", "engine", "port", "token"})
+	f.Outputs["widget_arn"] = &property{Type: "string"}
+	f.Terraform["widget_arn"] = &override{Name: "arn"}
+	f.Terraform["subpart"] = &override{Omit: true}
+	f.Terraform["host"] = &override{Validators: []string{"assetutil.WildcardFQDN()"}}
+
+	spec, err := newAssetSpec("widget", "bundle.json", f)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "aws", spec.Platform)
+	assert.Equal(t, "widget", spec.AssetType)
+	assert.False(t, spec.CustomInput)
+	assert.False(t, spec.CustomState)
+	assert.True(t, spec.UsesUtil)
+
+	attrs := map[string]attribute{}
+	names := []string{}
+	for _, a := range spec.Parameters {
+		attrs[a.Name] = a
+		names = append(names, a.Name)
+	}
+	// sorted, omitted ones left out
+	assert.Equal(t, []string{"engine", "host", "name", "port", "public", "tags", "token"}, names)
+
+	assert.Equal(t, "Name", attrs["name"].Field)
+	assert.True(t, attrs["name"].Required)
+	assert.Equal(t, "A name", attrs["name"].Description)
+	assert.Equal(t, []string{"assetutil.NotEmpty()"}, attrs["name"].Validators)
+	assert.Equal(t, []string{`assetutil.OneOf("a", "b")`}, attrs["engine"].Validators)
+	assert.Equal(t, []string{"assetutil.IntBetween(1, 10)"}, attrs["port"].Validators)
+	assert.Equal(t, "types.Int64", attrs["port"].GoType)
+	assert.Equal(t, []string{"assetutil.WildcardFQDN()"}, attrs["host"].Validators)
+
+	assert.True(t, attrs["public"].Optional)
+	assert.True(t, attrs["public"].Computed)
+	assert.True(t, attrs["tags"].Optional)
+	assert.False(t, attrs["tags"].Computed)
+	assert.Equal(t, "types.ListType{ElemType: types.StringType}", attrs["tags"].Type)
+	assert.True(t, attrs["token"].Sensitive)
+
+	if !assert.Len(t, spec.Outputs, 1) {
+		return
+	}
+	assert.Equal(t, "arn", spec.Outputs[0].Name)
+	assert.Equal(t, "widget_arn", spec.Outputs[0].Key)
+	assert.True(t, spec.Outputs[0].Computed)
+}
+
+func TestNewAssetSpecCustom(t *testing.T) {
+	f := testFixture(map[string]interface{}{
+		"secrets": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "object"}},
+	}, []string{"secrets"})
+
+	_, err := newAssetSpec("widget", "bundle.json", f)
+	assert.ErrorContains(t, err, "secrets")
+
+	f.Terraform["secrets"] = &override{Custom: true, GoType: "map[string]Secret", Schema: "secretsSchema"}
+	f.Terraform["connects_to"] = &override{Custom: true, property: property{Type: "array", Items: &property{Type: "string"}}}
+	spec, err := newAssetSpec("widget", "bundle.json", f)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, spec.CustomInput)
+	assert.True(t, spec.CustomState)
+	assert.False(t, spec.UsesUtil)
+	if !assert.Len(t, spec.Parameters, 2) {
+		return
+	}
+	assert.True(t, spec.Parameters[0].Extra)
+	assert.Equal(t, "map[string]Secret", spec.Parameters[1].GoType)
+
+	// an attribute the bundle doesn't know about can only be converted by hand
+	f.Terraform["unknown"] = &override{property: property{Type: "string"}}
+	_, err = newAssetSpec("widget", "bundle.json", f)
+	assert.ErrorContains(t, err, "unknown is neither a parameter nor an output")
+}
+
+func TestNewAssetSpecOverrides(t *testing.T) {
+	f := testFixture(map[string]interface{}{
+		"port":    map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 10},
+		"command": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+	}, []string{"port", "command"})
+	f.Outputs["db_identifier"] = &property{Type: "string"}
+	f.Terraform["db_identifier"] = &override{Field: "DBIdentifier"}
+	f.Terraform["port"] = &override{property: property{Type: "number"}}
+	f.Terraform["command"] = &override{GoType: "[]types.String"}
+
+	spec, err := newAssetSpec("widget", "bundle.json", f)
+	if !assert.Nil(t, err) || !assert.Len(t, spec.Parameters, 2) || !assert.Len(t, spec.Outputs, 1) {
+		return
+	}
+	command, port := spec.Parameters[0], spec.Parameters[1]
+	assert.Equal(t, "[]types.String", command.GoType)
+	assert.Equal(t, "types.ListType{ElemType: types.StringType}", command.Type)
+	assert.Equal(t, "util.StringsFromValues(plan.Command)", toInput(command))
+	assert.Equal(t, `util.StringsVal(params["command"])`, fromState(command))
+	assert.Equal(t, "types.Number", port.GoType)
+	assert.Equal(t, []string{"assetutil.IntBetween(1, 10)"}, port.Validators)
+	assert.Equal(t, "util.Float64FromNumber(plan.Port)", toInput(port))
+	assert.Equal(t, []string{"math/big"}, spec.Imports)
+	assert.Equal(t, "DBIdentifier", spec.Outputs[0].Field)
+
+	// other model types need a custom converter
+	f.Terraform["port"] = &override{GoType: "types.Number"}
+	_, err = newAssetSpec("widget", "bundle.json", f)
+	assert.ErrorContains(t, err, "only generated for custom attributes")
+}
+
+func TestFromState(t *testing.T) {
+	// an output the asset doesn't report is null, not an empty string
+	assert.Equal(t, `util.StringVal(outputs["url"].Data)`, fromState(attribute{Key: "url", Kind: "string", Output: true, Computed: true}))
+	assert.Equal(t, `util.Int64Val(outputs["port"].Data)`, fromState(attribute{Key: "port", Kind: "integer", Output: true, Computed: true}))
+	assert.Equal(t, `types.String{Value: util.SafeString(params["name"])}`, fromState(attribute{Key: "name", Kind: "string", Required: true}))
+	assert.Equal(t, `util.StringVal(params["note"])`, fromState(attribute{Key: "note", Kind: "string", Optional: true}))
+}
+
+func TestFindBundle(t *testing.T) {
+	bundles := []cac.AssetBundle{
+		{Identifier: "aws__vpc__v0.2.0"},
+		{Identifier: "aws__rds__v0.3.1"},
+	}
+
+	bundle, err := findBundle(bundles, "aws__rds__latest")
+	assert.Nil(t, err)
+	assert.Equal(t, "aws__rds__v0.3.1", bundle.Identifier)
+
+	_, err = findBundle(bundles, "aws__secret_manager__latest")
+	assert.ErrorContains(t, err, "does not allow aws secret_manager")
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+// fixture - the checked in bundle.json of an asset package
+type fixture struct {
+	Bundle    cac.AssetBundle      `json:"bundle"`
+	Outputs   map[string]*property `json:"outputs"`
+	Terraform map[string]*override `json:"terraform,omitempty"`
+}
+
+// property - the json schema keywords of a parameter or output the generator understands
+type property struct {
+	Type        string      `json:"type,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+	Pattern     string      `json:"pattern,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	MinLength   *int        `json:"minLength,omitempty"`
+	Minimum     *int64      `json:"minimum,omitempty"`
+	Maximum     *int64      `json:"maximum,omitempty"`
+	Items       *property   `json:"items,omitempty"`
+	WriteOnly   bool        `json:"writeOnly,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	// Sensitive - hide the value from plans, implied by writeOnly
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// userParameters - the json schema of the user_parameters of a bundle
+type userParameters struct {
+	Properties map[string]*property `json:"properties"`
+	Required   []string             `json:"required"`
+}
+
+// override - terraform specific settings of a parameter or output
+type override struct {
+	property
+	// Name - attribute name when it differs from the parameter or output
+	Name string `json:"name,omitempty"`
+	// Field - model field name when it isn't the camel case of the attribute name, e.g. DBIdentifier
+	Field string `json:"field,omitempty"`
+	// Validators - go expressions of the attribute validators, replacing the ones derived from the schema
+	Validators []string `json:"validators,omitempty"`
+	// Omit - the parameter is not an attribute, e.g. it is derived from another one by custom converters
+	Omit bool `json:"omit,omitempty"`
+	// Custom - the attribute is converted by the customPlanToAssetInput and customAssetOutputToPlan
+	// functions of the package. An attribute that isn't a parameter or output of the bundle must be custom.
+	Custom bool `json:"custom,omitempty"`
+	// GoType - model field type of a custom attribute, derived from the type when empty. A generated list
+	// of strings may be a []types.String
+	GoType string `json:"go_type,omitempty"`
+	// Schema - go expression of the schema of a custom attribute, derived from the schema when empty
+	Schema string `json:"schema,omitempty"`
+}
+
+// attribute - one generated attribute of the asset
+type attribute struct {
+	Key         string
+	Name        string
+	Field       string
+	Kind        string
+	GoType      string
+	Type        string
+	Schema      string
+	Description string
+	Required    bool
+	Optional    bool
+	Computed    bool
+	Sensitive   bool
+	Output      bool
+	// Extra - the attribute is not a parameter or output of the bundle
+	Extra      bool
+	Custom     bool
+	Validators []string
+}
+
+// assetSpec - what the file template needs
+type assetSpec struct {
+	Source     string
+	Package    string
+	Platform   string
+	AssetType  string
+	Parameters []attribute
+	Outputs    []attribute
+	// CustomInput - custom attributes need to be sent to the api
+	CustomInput bool
+	// CustomState - custom attributes need to be read back from the api
+	CustomState bool
+	// Imports - standard library imports beyond the ones every generated file needs
+	Imports []string
+	// UsesUtil - the converters use the internal util package
+	UsesUtil bool
+}
+
+func newAssetSpec(pkg, source string, f *fixture) (*assetSpec, error) {
+	platform, assetType, _ := client.SplitAsset(f.Bundle.Identifier)
+	if platform == "" || assetType == "" {
+		return nil, fmt.Errorf("bundle identifier %q is not platform__type__version", f.Bundle.Identifier)
+	}
+
+	params := userParameters{}
+	if f.Bundle.UserParameters != nil {
+		bts, err := json.Marshal(f.Bundle.UserParameters)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bts, &params); err != nil {
+			return nil, fmt.Errorf("user_parameters: %w", err)
+		}
+	}
+	required := map[string]bool{}
+	for _, key := range params.Required {
+		required[key] = true
+	}
+
+	spec := &assetSpec{Source: source, Package: pkg, Platform: platform, AssetType: assetType}
+	seen := map[string]bool{}
+	add := func(key string, p *property, output, extra bool) error {
+		o := f.Terraform[key]
+		seen[key] = true
+		if o != nil && o.Omit {
+			return nil
+		}
+		a, err := newAttribute(key, p, o, output, required[key])
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		a.Extra = extra
+		if output {
+			spec.Outputs = append(spec.Outputs, a)
+		} else {
+			spec.Parameters = append(spec.Parameters, a)
+		}
+		if a.Custom {
+			spec.CustomState = true
+			spec.CustomInput = spec.CustomInput || !output
+		}
+		return nil
+	}
+
+	for key, p := range params.Properties {
+		if err := add(key, p, false, false); err != nil {
+			return nil, err
+		}
+	}
+	for key, p := range f.Outputs {
+		if err := add(key, p, true, false); err != nil {
+			return nil, err
+		}
+	}
+	// attributes of the resource the bundle doesn't know about, e.g. connects_to
+	for key, o := range f.Terraform {
+		if seen[key] {
+			continue
+		}
+		if !o.Custom {
+			return nil, fmt.Errorf("%s is neither a parameter nor an output of the bundle and not custom", key)
+		}
+		if err := add(key, &property{}, false, true); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(spec.Parameters, func(i, j int) bool { return spec.Parameters[i].Name < spec.Parameters[j].Name })
+	sort.Slice(spec.Outputs, func(i, j int) bool { return spec.Outputs[i].Name < spec.Outputs[j].Name })
+	spec.Imports, spec.UsesUtil = imports(spec)
+	return spec, nil
+}
+
+func newAttribute(key string, p *property, o *override, output, required bool) (attribute, error) {
+	merged := *p
+	if o != nil {
+		merged = mergeProperty(merged, o.property)
+	}
+
+	a := attribute{
+		Key:         key,
+		Name:        key,
+		Kind:        merged.Type,
+		Description: merged.Description,
+		Output:      output,
+		Sensitive:   merged.WriteOnly || merged.Sensitive,
+		Validators:  validators(merged),
+	}
+	if o != nil {
+		if o.Name != "" {
+			a.Name = o.Name
+		}
+		if o.Validators != nil {
+			a.Validators = o.Validators
+		}
+		a.Custom = o.Custom
+		a.GoType = o.GoType
+		a.Schema = o.Schema
+	}
+	a.Field = fieldName(a.Name)
+	if o != nil && o.Field != "" {
+		a.Field = o.Field
+	}
+
+	switch {
+	case output:
+		a.Computed = true
+	case required:
+		a.Required = true
+	case merged.Default != nil:
+		// the backend fills in its default when the attribute is left unset
+		a.Optional = true
+		a.Computed = true
+	default:
+		a.Optional = true
+	}
+
+	switch merged.Type {
+	case "string":
+		a.Type = "types.StringType"
+	case "boolean":
+		a.Type = "types.BoolType"
+	case "integer":
+		a.Type = "types.Int64Type"
+	case "number":
+		a.Type = "types.NumberType"
+	case "array":
+		if merged.Items == nil || merged.Items.Type != "string" {
+			if !a.Custom || a.Schema == "" {
+				return a, fmt.Errorf("only lists of strings are generated, give custom lists a schema")
+			}
+		}
+		a.Kind = "list"
+		if a.GoType == goTypes["strings"] {
+			a.Kind = "strings"
+		}
+		a.Type = "types.ListType{ElemType: types.StringType}"
+	default:
+		if !a.Custom || a.Schema == "" || a.GoType == "" {
+			return a, fmt.Errorf("type %q is not generated, make the attribute custom with a schema and go_type", merged.Type)
+		}
+	}
+
+	if a.GoType == "" {
+		a.GoType = goTypes[a.Kind]
+	}
+	if !a.Custom && a.GoType != goTypes[a.Kind] {
+		return a, fmt.Errorf("go_type %s is only generated for custom attributes", a.GoType)
+	}
+	if a.Custom && a.Schema != "" && a.GoType == "" {
+		return a, fmt.Errorf("custom attribute with a schema needs a go_type")
+	}
+	return a, nil
+}
+
+var goTypes = map[string]string{
+	"string":  "types.String",
+	"boolean": "types.Bool",
+	"integer": "types.Int64",
+	"number":  "types.Number",
+	"list":    "types.List",
+	"strings": "[]types.String",
+}
+
+// mergeProperty - the property with what the override sets on top
+func mergeProperty(p, o property) property {
+	if o.Type != "" {
+		p.Type = o.Type
+	}
+	if o.Description != "" {
+		p.Description = o.Description
+	}
+	if o.Enum != nil {
+		p.Enum = o.Enum
+	}
+	if o.Pattern != "" {
+		p.Pattern = o.Pattern
+	}
+	if o.Format != "" {
+		p.Format = o.Format
+	}
+	if o.MinLength != nil {
+		p.MinLength = o.MinLength
+	}
+	if o.Minimum != nil {
+		p.Minimum = o.Minimum
+	}
+	if o.Maximum != nil {
+		p.Maximum = o.Maximum
+	}
+	if o.Items != nil {
+		p.Items = o.Items
+	}
+	if o.Default != nil {
+		p.Default = o.Default
+	}
+	p.WriteOnly = p.WriteOnly || o.WriteOnly
+	p.Sensitive = p.Sensitive || o.Sensitive
+	return p
+}
+
+// validators - the assetutil validators the json schema keywords of a property map to
+func validators(p property) []string {
+	out := []string{}
+	if len(p.Enum) > 0 {
+		quoted := []string{}
+		for _, value := range p.Enum {
+			quoted = append(quoted, fmt.Sprintf("%q", value))
+		}
+		out = append(out, fmt.Sprintf("assetutil.OneOf(%s)", strings.Join(quoted, ", ")))
+	}
+	if p.MinLength != nil && *p.MinLength > 0 {
+		out = append(out, "assetutil.NotEmpty()")
+	}
+	if p.Format == "hostname" {
+		out = append(out, "assetutil.FQDN()")
+	}
+	if p.Pattern != "" {
+		out = append(out, fmt.Sprintf(
+			"assetutil.Matches(regexp.MustCompile(%q), %q)",
+			p.Pattern, fmt.Sprintf("value must match %s", p.Pattern),
+		))
+	}
+	if p.Minimum != nil && p.Maximum != nil {
+		out = append(out, fmt.Sprintf("assetutil.IntBetween(%d, %d)", *p.Minimum, *p.Maximum))
+	}
+	return out
+}
+
+// fieldName - the go name of an attribute, e.g. VpcName for vpc_name
+func fieldName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// imports - the standard library packages the generated converters and validators need on top of the ones
+// always used, and whether they need the util package
+func imports(spec *assetSpec) ([]string, bool) {
+	needs := map[string]bool{}
+	usesUtil := false
+	for _, a := range append(append([]attribute{}, spec.Parameters...), spec.Outputs...) {
+		for _, v := range a.Validators {
+			if strings.Contains(v, "regexp.") {
+				needs["regexp"] = true
+			}
+		}
+		if a.Custom {
+			continue
+		}
+		usesUtil = true
+		if a.Kind == "number" && a.Required {
+			needs["math/big"] = true
+		}
+	}
+
+	imports := []string{}
+	for pkg := range needs {
+		imports = append(imports, pkg)
+	}
+	sort.Strings(imports)
+	return imports, usesUtil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+var fileTemplate = template.Must(template.New("models_gen.go").Funcs(template.FuncMap{
+	"quote":     func(s string) string { return fmt.Sprintf("%q", s) },
+	"join":      strings.Join,
+	"toInput":   toInput,
+	"fromState": fromState,
+}).Parse(`// Code generated by assetgen from {{.Source}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- range .Imports}}
+	{{quote .}}
+{{- end}}
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+{{- if .UsesUtil}}
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+{{- end}}
+)
+
+var resourceAssetType = {{quote .AssetType}}
+
+type ResourceModel struct {
+	Id             types.String        ` + "`" + `tfsdk:"id" json:"id"` + "`" + `
+	AssetVersion   types.String        ` + "`" + `tfsdk:"asset_version" json:"asset_version"` + "`" + `
+	EnvironmentId  types.String        ` + "`" + `tfsdk:"environment_id" json:"environment_id"` + "`" + `
+	OrganizationId types.String        ` + "`" + `tfsdk:"organization_id" json:"organization_id"` + "`" + `
+	Status         types.String        ` + "`" + `tfsdk:"status" json:"status"` + "`" + `
+	Timeouts       *assetutil.Timeouts ` + "`" + `tfsdk:"timeouts"` + "`" + `
+{{if .Parameters}}
+{{range .Parameters}}	{{template "field" .}}
+{{end}}{{end}}{{if .Outputs}}
+{{range .Outputs}}	{{template "field" .}}
+{{end}}{{end}}}
+
+var AssetSchema = map[string]tfsdk.Attribute{
+	"id": {
+		Description: "A valid asset id",
+		Type:        types.StringType,
+		Computed:    true,
+	},
+	"status": {
+		Type:     types.StringType,
+		Computed: true,
+	},
+	"environment_id": {
+		Description: "A valid environment id, defaults to the environment_id of the provider",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"organization_id": {
+		Description: "A valid organization id, defaults to the organization_id of the provider",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"asset_version": {
+		Description: "Version of the asset bundle, the latest one the environment allows when not set. Changing it upgrades the asset in place",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+{{range .Parameters}}{{template "schema" .}}{{end -}}
+{{range .Outputs}}{{template "schema" .}}{{end -}}
+}
+
+func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
+	params := map[string]interface{}{
+{{- range .Parameters}}{{if and .Required (not .Custom)}}
+		{{quote .Key}}: {{toInput .}},
+{{- end}}{{end}}
+	}
+{{- range .Parameters}}{{if and (not .Required) (not .Custom)}}
+	if !plan.{{.Field}}.IsNull() && !plan.{{.Field}}.IsUnknown() {
+		params[{{quote .Key}}] = {{toInput .}}
+	}
+{{- end}}{{end}}
+
+	input := cac.AssetInput{
+		Asset:           client.CompileAsset({{quote .Platform}}, resourceAssetType, assetutil.AssetVersion(plan.AssetVersion)),
+		AssetVersion:    assetutil.AssetVersion(plan.AssetVersion),
+		AssetParameters: params,
+	}
+{{- if .CustomInput}}
+
+	if err := customPlanToAssetInput(ctx, plan, &input); err != nil {
+		return input, err
+	}
+{{- end}}
+
+	return input, nil
+}
+
+func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
+{{- range .Parameters}}{{if not .Custom}}{{template "params"}}{{break}}{{end}}{{end}}
+{{- range .Outputs}}{{if not .Custom}}{{template "outputs"}}{{break}}{{end}}{{end}}
+
+	model := &ResourceModel{
+		Id:             types.String{Value: output.Id},
+		AssetVersion:   types.String{Value: output.AssetVersion},
+		EnvironmentId:  types.String{Value: output.Environment.Id},
+		OrganizationId: types.String{Value: output.Environment.Organization.Id},
+		Status:         types.String{Value: string(output.Status)},
+		Timeouts:       plan.Timeouts,
+{{- range .Parameters}}{{if not .Custom}}
+		{{.Field}}: {{fromState .}},
+{{- end}}{{end}}
+{{- range .Outputs}}{{if not .Custom}}
+		{{.Field}}: {{fromState .}},
+{{- end}}{{end}}
+	}
+{{- if .CustomState}}
+
+	if err := customAssetOutputToPlan(ctx, plan, output, model); err != nil {
+		return nil, err
+	}
+{{- end}}
+
+	return model, nil
+}
+{{define "params"}}
+	params := output.CurrentAssetParameters.Data
+{{- end}}
+{{define "outputs"}}
+	outputs := output.GetOutputs()
+{{- end}}
+{{define "field"}}{{.Field}} {{.GoType}} ` + "`" + `tfsdk:"{{.Name}}"{{if not .Extra}} json:"{{.Key}}"{{end}}` + "`" + `{{end}}
+{{define "schema"}}	{{quote .Name}}: {{if .Schema}}{{.Schema}},
+{{else}}{
+{{- if .Description}}
+		Description: {{quote .Description}},
+{{- end}}
+		Type: {{.Type}},
+{{- if .Required}}
+		Required: true,
+{{- end}}
+{{- if .Optional}}
+		Optional: true,
+{{- end}}
+{{- if .Computed}}
+		Computed: true,
+{{- end}}
+{{- if .Sensitive}}
+		Sensitive: true,
+{{- end}}
+{{- if .Validators}}
+		Validators: []tfsdk.AttributeValidator{ {{- join .Validators ", " -}} },
+{{- end}}
+	},
+{{end}}{{end}}`))
+
+// toInput - the go expression of the value the api expects for a planned attribute
+func toInput(a attribute) string {
+	switch a.Kind {
+	case "number":
+		return fmt.Sprintf("util.Float64FromNumber(plan.%s)", a.Field)
+	case "list":
+		return fmt.Sprintf("util.StringsFromList(ctx, plan.%s)", a.Field)
+	case "strings":
+		return fmt.Sprintf("util.StringsFromValues(plan.%s)", a.Field)
+	default:
+		return fmt.Sprintf("plan.%s.Value", a.Field)
+	}
+}
+
+// fromState - the go expression of an attribute read back from the parameters or outputs of the asset.
+// Optional parameters and outputs the asset doesn't have are null, required parameters are always set.
+func fromState(a attribute) string {
+	value := fmt.Sprintf("params[%q]", a.Key)
+	if a.Output {
+		value = fmt.Sprintf("outputs[%q].Data", a.Key)
+	}
+
+	if a.Kind == "strings" {
+		return fmt.Sprintf("util.StringsVal(%s)", value)
+	}
+	if a.Optional || a.Output {
+		switch a.Kind {
+		case "boolean":
+			return fmt.Sprintf("util.BoolVal(%s)", value)
+		case "integer":
+			return fmt.Sprintf("util.Int64Val(%s)", value)
+		case "number":
+			return fmt.Sprintf("util.NumberVal(%s)", value)
+		case "list":
+			return fmt.Sprintf("util.OptionalStringListVal(%s)", value)
+		default:
+			return fmt.Sprintf("util.StringVal(%s)", value)
+		}
+	}
+
+	switch a.Kind {
+	case "boolean":
+		return fmt.Sprintf("types.Bool{Value: util.SafeBool(%s)}", value)
+	case "integer":
+		return fmt.Sprintf("types.Int64{Value: util.SafeInt64(%s)}", value)
+	case "number":
+		return fmt.Sprintf("types.Number{Value: big.NewFloat(util.SafeFloat64(%s))}", value)
+	case "list":
+		return fmt.Sprintf("util.StringListVal(%s)", value)
+	default:
+		return fmt.Sprintf("types.String{Value: util.SafeString(%s)}", value)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Package assettest drives framework resources through their CRUD methods the
+same way terraform does, so they can be unit tested against the fake client.
+*/
+package assettest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fake"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Configure - inject a client as ProviderData, like the provider does
+func Configure(t *testing.T, r resource.Resource, c client.CloudClient) {
+	t.Helper()
+
+	ConfigureProvider(t, r, &util.ProviderData{Client: c})
+}
+
+// ConfigureProvider - inject the provider data, e.g. with default ids, like the provider does
+func ConfigureProvider(t *testing.T, r resource.Resource, data *util.ProviderData) {
+	t.Helper()
+
+	configurable, ok := r.(resource.ResourceWithConfigure)
+	if !ok {
+		t.Fatalf("%T does not implement resource.ResourceWithConfigure", r)
+	}
+
+	resp := &resource.ConfigureResponse{}
+	configurable.Configure(context.Background(), resource.ConfigureRequest{ProviderData: data}, resp)
+	RequireNoError(t, resp.Diagnostics)
+}
+
+// Schema - the schema of a resource, failing the test on error
+func Schema(t *testing.T, r resource.Resource) tfsdk.Schema {
+	t.Helper()
+
+	schema, diags := r.GetSchema(context.Background())
+	RequireNoError(t, diags)
+	return schema
+}
+
+// ValidateAttribute - run the validators of a top level attribute of the resource schema against a
+// configured value, as terraform validate does
+func ValidateAttribute(t *testing.T, r resource.Resource, name string, value attr.Value) diag.Diagnostics {
+	t.Helper()
+
+	attribute, ok := Schema(t, r).Attributes[name]
+	if !ok {
+		t.Fatalf("%T has no attribute %s", r, name)
+	}
+
+	resp := &tfsdk.ValidateAttributeResponse{}
+	for _, validator := range attribute.Validators {
+		validator.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root(name),
+			AttributeConfig: value,
+		}, resp)
+	}
+	return resp.Diagnostics
+}
+
+// Plan - build a plan out of a resource model
+func Plan(t *testing.T, r resource.Resource, model interface{}) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: Schema(t, r)}
+	RequireNoError(t, plan.Set(context.Background(), model))
+	return plan
+}
+
+// State - build a state out of a resource model
+func State(t *testing.T, r resource.Resource, model interface{}) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{Schema: Schema(t, r)}
+	RequireNoError(t, state.Set(context.Background(), model))
+	return state
+}
+
+// Create - call Create with the model as plan and return the response
+func Create(t *testing.T, r resource.Resource, model interface{}) *resource.CreateResponse {
+	t.Helper()
+
+	plan := Plan(t, r, model)
+	resp := &resource.CreateResponse{State: emptyState(t, r)}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan, Config: tfsdk.Config(plan)}, resp)
+	return resp
+}
+
+// ModifyPlan - call ModifyPlan for a new resource with the configuration and the plan terraform derived
+// from it, and return the response
+func ModifyPlan(t *testing.T, r resource.Resource, config, planned interface{}) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	modifiable, ok := r.(resource.ResourceWithModifyPlan)
+	if !ok {
+		t.Fatalf("%T does not implement resource.ResourceWithModifyPlan", r)
+	}
+
+	plan := Plan(t, r, planned)
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	modifiable.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config(Plan(t, r, config)),
+		Plan:   plan,
+		State:  emptyState(t, r),
+	}, resp)
+	return resp
+}
+
+// Read - call Read with the model as prior state and return the response
+func Read(t *testing.T, r resource.Resource, model interface{}) *resource.ReadResponse {
+	t.Helper()
+
+	state := State(t, r, model)
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+// Update - call Update moving from prior to planned and return the response
+func Update(t *testing.T, r resource.Resource, prior, planned interface{}) *resource.UpdateResponse {
+	t.Helper()
+
+	plan := Plan(t, r, planned)
+	resp := &resource.UpdateResponse{State: State(t, r, prior)}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:   plan,
+		Config: tfsdk.Config(plan),
+		State:  State(t, r, prior),
+	}, resp)
+	return resp
+}
+
+// Delete - call Delete with the model as prior state and return the response
+func Delete(t *testing.T, r resource.Resource, model interface{}) *resource.DeleteResponse {
+	t.Helper()
+
+	state := State(t, r, model)
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+	return resp
+}
+
+// ImportState - call ImportState with the import id and return the response
+func ImportState(t *testing.T, r resource.Resource, id string) *resource.ImportStateResponse {
+	t.Helper()
+
+	importable, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		t.Fatalf("%T does not implement resource.ResourceWithImportState", r)
+	}
+
+	resp := &resource.ImportStateResponse{State: emptyState(t, r)}
+	importable.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+	return resp
+}
+
+// Get - decode a state into a resource model
+func Get(t *testing.T, state tfsdk.State, target interface{}) {
+	t.Helper()
+
+	RequireNoError(t, state.Get(context.Background(), target))
+}
+
+// IsRemoved - whether the resource has been removed from state
+func IsRemoved(state tfsdk.State) bool {
+	return state.Raw.IsNull()
+}
+
+// RequireNoError - fail the test immediately when diagnostics contain an error
+func RequireNoError(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		for _, d := range diags.Errors() {
+			t.Errorf("%s: %s", d.Summary(), d.Detail())
+		}
+		t.FailNow()
+	}
+}
+
+func emptyState(t *testing.T, r resource.Resource) tfsdk.State {
+	schema := Schema(t, r)
+	return tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+// Setup - a fake client seeded with an organization and environment, with the asset waiter sped up
+func Setup(t *testing.T) (*fake.Client, cac.EnvironmentOutput) {
+	t.Helper()
+
+	previous := util.DefaultTimeToWait
+	util.DefaultTimeToWait = time.Millisecond
+	t.Cleanup(func() { util.DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	org := c.AddOrg("test-org")
+	env := c.AddEnvironment(org.Id, "test-env")
+	return c, env
+}
+
+// ImportId - the id terraform import expects for an asset
+func ImportId(env cac.EnvironmentOutput, assetId string) string {
+	return fmt.Sprintf("%s,%s,%s", env.Organization.Id, env.Id, assetId)
+}
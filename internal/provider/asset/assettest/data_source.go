@@ -0,0 +1,87 @@
+package assettest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// ConfigureDataSource - inject a client as ProviderData, like the provider does, without default ids
+func ConfigureDataSource(t *testing.T, d datasource.DataSource, c client.CloudClient) {
+	t.Helper()
+
+	configurable, ok := d.(datasource.DataSourceWithConfigure)
+	if !ok {
+		t.Fatalf("%T does not implement datasource.DataSourceWithConfigure", d)
+	}
+
+	resp := &datasource.ConfigureResponse{}
+	configurable.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: &util.ProviderData{Client: c}}, resp)
+	RequireNoError(t, resp.Diagnostics)
+}
+
+// ReadDataSource - call Read with the model as configuration, computed attributes left null, and return
+// the response
+func ReadDataSource(t *testing.T, d datasource.DataSource, model interface{}) *datasource.ReadResponse {
+	t.Helper()
+
+	schema, diags := d.GetSchema(context.Background())
+	RequireNoError(t, diags)
+
+	config := tfsdk.State{Schema: schema}
+	RequireNoError(t, config.Set(context.Background(), model))
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema, Raw: config.Raw.Copy()}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config(config)}, resp)
+	return resp
+}
+
+// ReadDataSourceAttributes - call Read with the string attributes as configuration, every other attribute
+// left null, and return the response
+func ReadDataSourceAttributes(t *testing.T, d datasource.DataSource, attributes map[string]string) *datasource.ReadResponse {
+	t.Helper()
+
+	schema, diags := d.GetSchema(context.Background())
+	RequireNoError(t, diags)
+
+	objectType := schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	config := tfsdk.Config{Schema: schema, Raw: tftypes.NewValue(objectType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema, Raw: config.Raw.Copy()}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+// ValidateDataSourceConfig - call ValidateConfig with the model as configuration and return its diagnostics
+func ValidateDataSourceConfig(t *testing.T, d datasource.DataSource, model interface{}) diag.Diagnostics {
+	t.Helper()
+
+	validatable, ok := d.(datasource.DataSourceWithValidateConfig)
+	if !ok {
+		t.Fatalf("%T does not implement datasource.DataSourceWithValidateConfig", d)
+	}
+
+	schema, diags := d.GetSchema(context.Background())
+	RequireNoError(t, diags)
+
+	config := tfsdk.State{Schema: schema}
+	RequireNoError(t, config.Set(context.Background(), model))
+
+	resp := &datasource.ValidateConfigResponse{}
+	validatable.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: tfsdk.Config(config)}, resp)
+	return resp.Diagnostics
+}
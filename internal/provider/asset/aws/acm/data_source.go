@@ -0,0 +1,24 @@
+package acm
+
+import (
+	"context"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+)
+
+// NewDataSource - an existing asset looked up by its fqdn, with the same attributes as the resource
+func NewDataSource() datasource.DataSource {
+	return assetutil.NewDataSource(assetutil.DataSourceConfig{
+		TypeName:    resourceTypeName,
+		AssetType:   resourceAssetType,
+		LookupKey:   "fqdn",
+		Description: "An ACM certificate of an environment, found by its fqdn, e.g. to use it from another workspace",
+		NewResource: NewResource,
+		ToState: func(ctx context.Context, output *cac.AssetOutput) (interface{}, error) {
+			return assetOutputToPlan(ctx, ResourceModel{}, output)
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package acm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestDataSourceByFqdn(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	created := assettest.Create(t, r, ResourceModel{
+		Id:               types.String{Unknown: true},
+		AssetVersion:     types.String{Unknown: true},
+		Status:           types.String{Unknown: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		Fqdn:             types.String{Value: "www.example.com"},
+		ValidationMethod: types.String{Value: "DNS"},
+		Arn:              types.String{Unknown: true},
+		DomainValidationRecords: types.List{
+			Unknown: true,
+			ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"domain_name":           types.StringType,
+				"resource_record_name":  types.StringType,
+				"resource_record_type":  types.StringType,
+				"resource_record_value": types.StringType,
+			}},
+		},
+	})
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	resp := assettest.ReadDataSourceAttributes(t, d, map[string]string{
+		"organization_id": env.Organization.Id,
+		"environment_id":  env.Id,
+		"fqdn":            "www.example.com",
+	})
+	assettest.RequireNoError(t, resp.Diagnostics)
+
+	var arn types.String
+	assettest.RequireNoError(t, resp.State.GetAttribute(context.Background(), path.Root("arn"), &arn))
+	assert.Equal(t, state.Arn, arn)
+
+	records := []DnsValidationRecord{}
+	assettest.RequireNoError(t, resp.State.GetAttribute(context.Background(), path.Root("domain_validation_records"), &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, "www.example.com", records[0].DomainName.Value)
+}
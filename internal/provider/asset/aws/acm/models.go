@@ -1,22 +1,47 @@
 package acm
 
+//go:generate go run ../../assetgen
+
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	cac "github.com/aptible/cloud-api-clients/clients/go"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
 )
 
 var resourceTypeName = "_aws_acm"
 var resourceDescription = "ACM Certificate resource"
 
+var immutableAttributes = []string{"organization_id", "environment_id", "fqdn", "validation_method"}
+
+var resourceTimeouts = assetutil.TimeoutDefaults{
+	Create: 15 * time.Minute,
+	Update: 15 * time.Minute,
+	Delete: 15 * time.Minute,
+}
+
+func NewResource() resource.Resource {
+	return asset.NewResource(asset.ResourceConfig[ResourceModel]{
+		TypeName:            resourceTypeName,
+		Description:         resourceDescription,
+		Platform:            "aws",
+		AssetType:           resourceAssetType,
+		Schema:              AssetSchema,
+		ImmutableAttributes: immutableAttributes,
+		Timeouts:            resourceTimeouts,
+		ToAssetInput:        planToAssetInput,
+		ToState:             assetOutputToPlan,
+	})
+}
+
 type DnsValidationRecordJson struct {
 	DomainName  string `json:"domain_name"`
 	RecordName  string `json:"resource_record_name"`
@@ -31,105 +56,42 @@ type DnsValidationRecord struct {
 	RecordValue types.String `tfsdk:"resource_record_value"`
 }
 
-// TODO - autogenerated
-type ResourceModel struct {
-	Id             types.String `tfsdk:"id" json:"id"`
-	AssetVersion   types.String `tfsdk:"asset_version" json:"asset_version"`
-	EnvironmentId  types.String `tfsdk:"environment_id" json:"environment_id"`
-	OrganizationId types.String `tfsdk:"organization_id" json:"organization_id"`
-	Status         types.String `tfsdk:"status" json:"status"`
-
-	Fqdn                    types.String `tfsdk:"fqdn" json:"fqdn"`
-	ValidationMethod        types.String `tfsdk:"validation_method" json:"validation_method"`
-	Arn                     types.String `tfsdk:"arn" json:"arn"`
-	DomainValidationRecords types.List   `tfsdk:"domain_validation_records"`
-}
-
-var AssetSchema = map[string]tfsdk.Attribute{
-	"id": {
-		Description: "A valid asset id",
-		Type:        types.StringType,
-		Computed:    true,
-	},
-	"status": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-
-	"environment_id": {
-		Description: "A valid environment id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"organization_id": {
-		Description: "A valid organization id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"asset_version": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-	"fqdn": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"validation_method": {
-		Description: "A valid validation method",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"arn": {
-		Computed: true,
-		Type:     types.StringType,
-	},
-	"domain_validation_records": {
-		Computed: true,
-		Optional: true,
-		Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-			"domain_name": {
-				Type:     types.StringType,
-				Computed: true,
-				Optional: true,
-			},
-			"resource_record_name": {
-				Type:     types.StringType,
-				Computed: true,
-				Optional: true,
-			},
-			"resource_record_type": {
-				Type:     types.StringType,
-				Computed: true,
-				Optional: true,
-			},
-			"resource_record_value": {
-				Type:     types.StringType,
-				Computed: true,
-				Optional: true,
-			},
-		}),
-	},
-}
-
-func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
-	input := cac.AssetInput{
-		Asset:        client.CompileAsset("aws", "acm_certificate", assetutil.DefaultAssetVersion),
-		AssetVersion: assetutil.DefaultAssetVersion,
-		AssetParameters: map[string]interface{}{
-			"fqdn":              plan.Fqdn.Value,
-			"validation_method": plan.ValidationMethod.Value,
+// domainValidationRecordsSchema - the records to create for DNS validation, read from the
+// dns_validation_records output
+var domainValidationRecordsSchema = tfsdk.Attribute{
+	Computed: true,
+	Optional: true,
+	Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+		"domain_name": {
+			Type:     types.StringType,
+			Computed: true,
+			Optional: true,
 		},
-	}
-
-	return input, nil
+		"resource_record_name": {
+			Type:     types.StringType,
+			Computed: true,
+			Optional: true,
+		},
+		"resource_record_type": {
+			Type:     types.StringType,
+			Computed: true,
+			Optional: true,
+		},
+		"resource_record_value": {
+			Type:     types.StringType,
+			Computed: true,
+			Optional: true,
+		},
+	}),
 }
 
 type DnsData struct {
 	Data []DnsValidationRecordJson `json:"data"`
 }
 
-func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
-	outputs := *output.Outputs
+// customAssetOutputToPlan - read the dns_validation_records output into domain_validation_records
+func customAssetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput, model *ResourceModel) error {
+	outputs := output.GetOutputs()
 
 	mapper := map[string]attr.Type{
 		"domain_name":           types.StringType,
@@ -143,11 +105,11 @@ func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.Asse
 		recordsJson := DnsData{Data: []DnsValidationRecordJson{}}
 		bts, err := json.Marshal(recs)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		err = json.Unmarshal(bts, &recordsJson)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		for _, record := range recordsJson.Data {
@@ -163,17 +125,6 @@ func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.Asse
 		}
 	}
 
-	model := &ResourceModel{
-		Id:                      types.String{Value: output.Id},
-		AssetVersion:            types.String{Value: output.AssetVersion},
-		EnvironmentId:           types.String{Value: output.Environment.Id},
-		OrganizationId:          types.String{Value: output.Environment.Organization.Id},
-		Status:                  types.String{Value: string(output.Status)},
-		Fqdn:                    types.String{Value: output.CurrentAssetParameters.Data["fqdn"].(string)},
-		ValidationMethod:        types.String{Value: output.CurrentAssetParameters.Data["validation_method"].(string)},
-		Arn:                     types.String{Value: util.SafeString(outputs["acm_certificate_arn"].Data)},
-		DomainValidationRecords: types.List{Elems: records, ElemType: types.ObjectType{AttrTypes: mapper}},
-	}
-
-	return model, nil
+	model.DomainValidationRecords = types.List{Elems: records, ElemType: types.ObjectType{AttrTypes: mapper}}
+	return nil
 }
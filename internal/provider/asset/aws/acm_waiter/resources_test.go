@@ -0,0 +1,72 @@
+package acmwaiter
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:             types.String{Unknown: true},
+		AssetVersion:   types.String{Unknown: true},
+		Status:         types.String{Unknown: true},
+		EnvironmentId:  types.String{Value: env.Id},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		CertificateArn: types.String{Value: "arn:aws:acm:us-east-1:000000000000:certificate/abc"},
+		ValidationFqdns: types.List{
+			ElemType: types.StringType,
+			Elems:    []attr.Value{types.String{Value: "_validation.www.example.com"}},
+		},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, plan.ValidationFqdns, state.ValidationFqdns)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.ValidationFqdns = types.List{
+		ElemType: types.StringType,
+		Elems: []attr.Value{
+			types.String{Value: "_validation.www.example.com"},
+			types.String{Value: "_validation.api.example.com"},
+		},
+	}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Len(t, state.ValidationFqdns.Elems, 2)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
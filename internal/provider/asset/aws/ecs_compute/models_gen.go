@@ -0,0 +1,189 @@
+// Code generated by assetgen from bundle.json; DO NOT EDIT.
+
+package ecscompute
+
+import (
+	"context"
+	"math/big"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+var resourceAssetType = "ecs_compute_service"
+
+type ResourceModel struct {
+	Id             types.String        `tfsdk:"id" json:"id"`
+	AssetVersion   types.String        `tfsdk:"asset_version" json:"asset_version"`
+	EnvironmentId  types.String        `tfsdk:"environment_id" json:"environment_id"`
+	OrganizationId types.String        `tfsdk:"organization_id" json:"organization_id"`
+	Status         types.String        `tfsdk:"status" json:"status"`
+	Timeouts       *assetutil.Timeouts `tfsdk:"timeouts"`
+
+	ConnectsTo                 types.List     `tfsdk:"connects_to"`
+	ContainerCommand           []types.String `tfsdk:"container_command" json:"container_command"`
+	ContainerImage             types.String   `tfsdk:"container_image" json:"container_image"`
+	ContainerName              types.String   `tfsdk:"container_name" json:"container_name"`
+	ContainerPort              types.Number   `tfsdk:"container_port" json:"container_port"`
+	ContainerRegistrySecretArn types.String   `tfsdk:"container_registry_secret_arn" json:"container_registry_secret_arn"`
+	EnvironmentSecrets         map[string]Env `tfsdk:"environment_secrets" json:"environment_secrets"`
+	IsEcrImage                 types.Bool     `tfsdk:"is_ecr_image" json:"is_ecr_image"`
+	Name                       types.String   `tfsdk:"name" json:"name"`
+	VpcName                    types.String   `tfsdk:"vpc_name" json:"vpc_name"`
+	WaitForSteadyState         types.Bool     `tfsdk:"wait_for_steady_state" json:"wait_for_steady_state"`
+}
+
+var AssetSchema = map[string]tfsdk.Attribute{
+	"id": {
+		Description: "A valid asset id",
+		Type:        types.StringType,
+		Computed:    true,
+	},
+	"status": {
+		Type:     types.StringType,
+		Computed: true,
+	},
+	"environment_id": {
+		Description: "A valid environment id, defaults to the environment_id of the provider",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"organization_id": {
+		Description: "A valid organization id, defaults to the organization_id of the provider",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"asset_version": {
+		Description: "Version of the asset bundle, the latest one the environment allows when not set. Changing it upgrades the asset in place",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"connects_to": {
+		Type:     types.ListType{ElemType: types.StringType},
+		Optional: true,
+	},
+	"container_command": {
+		Type:     types.ListType{ElemType: types.StringType},
+		Required: true,
+	},
+	"container_image": {
+		Type:       types.StringType,
+		Required:   true,
+		Validators: []tfsdk.AttributeValidator{assetutil.NotEmpty()},
+	},
+	"container_name": {
+		Type:       types.StringType,
+		Required:   true,
+		Validators: []tfsdk.AttributeValidator{assetutil.NotEmpty()},
+	},
+	"container_port": {
+		Type:       types.NumberType,
+		Required:   true,
+		Validators: []tfsdk.AttributeValidator{assetutil.IntBetween(1, 65535)},
+	},
+	"container_registry_secret_arn": {
+		Type:       types.StringType,
+		Optional:   true,
+		Validators: []tfsdk.AttributeValidator{assetutil.ARN("secretsmanager")},
+	},
+	"environment_secrets": environmentSecretsSchema,
+	"is_ecr_image": {
+		Type:     types.BoolType,
+		Optional: true,
+		Computed: true,
+	},
+	"name": {
+		Type:       types.StringType,
+		Required:   true,
+		Validators: []tfsdk.AttributeValidator{assetutil.NotEmpty()},
+	},
+	"vpc_name": {
+		Description: "A valid vpc name",
+		Type:        types.StringType,
+		Required:    true,
+		Validators:  []tfsdk.AttributeValidator{assetutil.NotEmpty()},
+	},
+	"wait_for_steady_state": {
+		Type:     types.BoolType,
+		Optional: true,
+		Computed: true,
+	},
+}
+
+func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
+	params := map[string]interface{}{
+		"container_command": util.StringsFromValues(plan.ContainerCommand),
+		"container_image":   plan.ContainerImage.Value,
+		"container_name":    plan.ContainerName.Value,
+		"container_port":    util.Float64FromNumber(plan.ContainerPort),
+		"name":              plan.Name.Value,
+		"vpc_name":          plan.VpcName.Value,
+	}
+	if !plan.ContainerRegistrySecretArn.IsNull() && !plan.ContainerRegistrySecretArn.IsUnknown() {
+		params["container_registry_secret_arn"] = plan.ContainerRegistrySecretArn.Value
+	}
+	if !plan.IsEcrImage.IsNull() && !plan.IsEcrImage.IsUnknown() {
+		params["is_ecr_image"] = plan.IsEcrImage.Value
+	}
+	if !plan.WaitForSteadyState.IsNull() && !plan.WaitForSteadyState.IsUnknown() {
+		params["wait_for_steady_state"] = plan.WaitForSteadyState.Value
+	}
+
+	input := cac.AssetInput{
+		Asset:           client.CompileAsset("aws", resourceAssetType, assetutil.AssetVersion(plan.AssetVersion)),
+		AssetVersion:    assetutil.AssetVersion(plan.AssetVersion),
+		AssetParameters: params,
+	}
+
+	if err := customPlanToAssetInput(ctx, plan, &input); err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
+
+func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
+	params := output.CurrentAssetParameters.Data
+
+	model := &ResourceModel{
+		Id:                         types.String{Value: output.Id},
+		AssetVersion:               types.String{Value: output.AssetVersion},
+		EnvironmentId:              types.String{Value: output.Environment.Id},
+		OrganizationId:             types.String{Value: output.Environment.Organization.Id},
+		Status:                     types.String{Value: string(output.Status)},
+		Timeouts:                   plan.Timeouts,
+		ContainerCommand:           util.StringsVal(params["container_command"]),
+		ContainerImage:             types.String{Value: util.SafeString(params["container_image"])},
+		ContainerName:              types.String{Value: util.SafeString(params["container_name"])},
+		ContainerPort:              types.Number{Value: big.NewFloat(util.SafeFloat64(params["container_port"]))},
+		ContainerRegistrySecretArn: util.StringVal(params["container_registry_secret_arn"]),
+		IsEcrImage:                 util.BoolVal(params["is_ecr_image"]),
+		Name:                       types.String{Value: util.SafeString(params["name"])},
+		VpcName:                    types.String{Value: util.SafeString(params["vpc_name"])},
+		WaitForSteadyState:         util.BoolVal(params["wait_for_steady_state"]),
+	}
+
+	if err := customAssetOutputToPlan(ctx, plan, output, model); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
@@ -0,0 +1,78 @@
+package ecscompute
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:                         types.String{Unknown: true},
+		AssetVersion:               types.String{Unknown: true},
+		Status:                     types.String{Unknown: true},
+		EnvironmentId:              types.String{Value: env.Id},
+		OrganizationId:             types.String{Value: env.Organization.Id},
+		VpcName:                    types.String{Value: "network"},
+		Name:                       types.String{Value: "worker"},
+		ContainerName:              types.String{Value: "worker"},
+		ContainerPort:              types.Number{Value: big.NewFloat(8080)},
+		ContainerImage:             types.String{Value: "quay.io/aptible/worker:latest"},
+		ContainerCommand:           []types.String{{Value: "bin/worker"}},
+		ContainerRegistrySecretArn: types.String{Value: "arn:aws:secretsmanager:us-east-1:000000000000:secret/registry"},
+		EnvironmentSecrets:         map[string]Env{},
+		ConnectsTo:                 types.List{ElemType: types.StringType, Null: true},
+		IsEcrImage:                 types.Bool{Unknown: true},
+		WaitForSteadyState:         types.Bool{Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, 0, big.NewFloat(8080).Cmp(state.ContainerPort.Value))
+	assert.Equal(t, plan.ContainerRegistrySecretArn, state.ContainerRegistrySecretArn)
+	assert.Equal(t, plan.ContainerCommand, state.ContainerCommand)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state.ContainerImage, refreshed.ContainerImage)
+
+	planned := state
+	planned.ContainerCommand = []types.String{{Value: "bin/worker"}, {Value: "--verbose"}}
+	planned.ConnectsTo = types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "cache-asset"}}}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, planned.ContainerCommand, state.ContainerCommand)
+	assert.Equal(t, planned.ConnectsTo, state.ConnectsTo)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state.Id, importedState.Id)
+	assert.Equal(t, state.ContainerCommand, importedState.ContainerCommand)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
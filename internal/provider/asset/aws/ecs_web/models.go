@@ -1,17 +1,21 @@
 package ecsweb
 
+//go:generate go run ../../assetgen
+
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math/big"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	cac "github.com/aptible/cloud-api-clients/clients/go"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset"
 	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
 )
@@ -19,6 +23,28 @@ import (
 var resourceTypeName = "_aws_ecs_web"
 var resourceDescription = "ECS web resource"
 
+var immutableAttributes = []string{"organization_id", "environment_id", "vpc_name", "name", "is_public"}
+
+var resourceTimeouts = assetutil.TimeoutDefaults{
+	Create: 30 * time.Minute,
+	Update: 30 * time.Minute,
+	Delete: 20 * time.Minute,
+}
+
+func NewResource() resource.Resource {
+	return asset.NewResource(asset.ResourceConfig[ResourceModel]{
+		TypeName:            resourceTypeName,
+		Description:         resourceDescription,
+		Platform:            "aws",
+		AssetType:           resourceAssetType,
+		Schema:              AssetSchema,
+		ImmutableAttributes: immutableAttributes,
+		Timeouts:            resourceTimeouts,
+		ToAssetInput:        planToAssetInput,
+		ToState:             assetOutputToPlan,
+	})
+}
+
 type Env struct {
 	SecretArn     types.String `tfsdk:"secret_arn" json:"secret_arn"`
 	SecretJsonKey types.String `tfsdk:"secret_json_key" json:"secret_json_key"`
@@ -30,138 +56,25 @@ type EnvJson struct {
 	SecretJsonKey string `json:"secret_json_key"`
 }
 
-// TODO - autogenerated
-type ResourceModel struct {
-	Id             types.String `tfsdk:"id" json:"id"`
-	AssetVersion   types.String `tfsdk:"asset_version" json:"asset_version"`
-	EnvironmentId  types.String `tfsdk:"environment_id" json:"environment_id"`
-	OrganizationId types.String `tfsdk:"organization_id" json:"organization_id"`
-	Status         types.String `tfsdk:"status" json:"status"`
-
-	VpcName                    types.String   `tfsdk:"vpc_name" json:"vpc_name"`
-	Name                       types.String   `tfsdk:"name" json:"name"`
-	IsPublic                   types.Bool     `tfsdk:"is_public" json:"is_public"`
-	IsEcrImage                 types.Bool     `tfsdk:"is_ecr_image"`
-	ContainerName              types.String   `tfsdk:"container_name" json:"container_name"`
-	ContainerPort              types.Number   `tfsdk:"container_port" json:"container_port"`
-	ContainerImage             types.String   `tfsdk:"container_image" json:"container_image"`
-	ContainerCommand           []types.String `tfsdk:"container_command" json:"container_command"`
-	EnvironmentSecrets         map[string]Env `tfsdk:"environment_secrets" json:"environment_secrets"`
-	LbCertArn                  types.String   `tfsdk:"lb_cert_arn" json:"lb_cert_arn"`
-	LbCertDomain               types.String   `tfsdk:"lb_cert_domain" json:"lb_cert_domain"`
-	ConnectsTo                 types.List     `tfsdk:"connects_to"`
-	ContainerRegistrySecretArn types.String   `tfsdk:"container_registry_secret_arn"`
-	LoadBalancerUrl            types.String   `tfsdk:"load_balancer_url"`
-	WaitForSteadyState         types.Bool     `tfsdk:"wait_for_steady_state"`
-}
-
-var AssetSchema = map[string]tfsdk.Attribute{
-	"id": {
-		Description: "A valid asset id",
-		Type:        types.StringType,
-		Computed:    true,
-	},
-	"status": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-
-	"environment_id": {
-		Description: "A valid environment id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"organization_id": {
-		Description: "A valid organization id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"vpc_name": {
-		Description: "A valid vpc name",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"asset_version": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-	"name": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"is_public": {
-		Type:     types.BoolType,
-		Required: true,
-	},
-	"is_ecr_image": {
-		Type:     types.BoolType,
-		Optional: true,
-	},
-	"lb_cert_arn": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"lb_cert_domain": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"container_name": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"container_image": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"container_registry_secret_arn": {
-		Type:     types.StringType,
-		Optional: true,
-	},
-	"container_port": {
-		Type:     types.NumberType,
-		Required: true,
-	},
-	"container_command": {
-		Type:     types.ListType{ElemType: types.StringType},
-		Required: true,
-	},
-	"connects_to": {
-		Type:     types.ListType{ElemType: types.StringType},
-		Optional: true,
-	},
-	"load_balancer_url": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-	"environment_secrets": {
-		Required: true,
-		Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
-			"secret_arn": {
-				Type:     types.StringType,
-				Required: true,
-			},
-			"secret_json_key": {
-				Type:     types.StringType,
-				Required: true,
-			},
-		}),
-	},
-	"wait_for_steady_state": {
-		Type:     types.BoolType,
-		Optional: true,
-		Computed: true, // if unset, will default to false returned by backend
-	},
+// environmentSecretsSchema - the secrets exposed to the container, keyed by environment variable
+var environmentSecretsSchema = tfsdk.Attribute{
+	Required: true,
+	Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
+		"secret_arn": {
+			Type:       types.StringType,
+			Required:   true,
+			Validators: []tfsdk.AttributeValidator{assetutil.ARN("secretsmanager")},
+		},
+		"secret_json_key": {
+			Type:     types.StringType,
+			Required: true,
+		},
+	}),
 }
 
-func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
-	// TODO HACK: https://aptible.slack.com/archives/C03C2STPTDX/p1664478414991299
-	dd := strings.SplitN(plan.LbCertDomain.Value, ".", 2)
-
-	cmd := []string{}
-	for _, c := range plan.ContainerCommand {
-		cmd = append(cmd, c.Value)
-	}
-
+// customPlanToAssetInput - send environment_secrets as the list the api expects, lb_cert_domain split
+// into the domain and subdomain the api expects, and connects_to, which isn't a parameter of the asset
+func customPlanToAssetInput(ctx context.Context, plan ResourceModel, input *cac.AssetInput) error {
 	secrets := []EnvJson{}
 	for k, v := range plan.EnvironmentSecrets {
 		secrets = append(secrets, EnvJson{
@@ -170,76 +83,49 @@ func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput,
 			SecretJsonKey: v.SecretJsonKey.Value,
 		})
 	}
+	input.AssetParameters["environment_secrets"] = secrets
 
-	params := map[string]interface{}{
-		"vpc_name":            plan.VpcName.Value,
-		"name":                plan.Name.Value,
-		"is_public":           plan.IsPublic.Value,
-		"lb_cert_arn":         plan.LbCertArn.Value,
-		"lb_cert_domain":      dd[1],
-		"lb_cert_subdomain":   dd[0],
-		"container_name":      plan.ContainerName.Value,
-		"container_image":     plan.ContainerImage.Value,
-		"container_port":      plan.ContainerPort.Value,
-		"container_command":   cmd,
-		"environment_secrets": secrets,
-	}
-
-	if !plan.ContainerRegistrySecretArn.IsNull() && !plan.ContainerRegistrySecretArn.IsUnknown() {
-		params["container_registry_secret_arn"] = plan.ContainerRegistrySecretArn.Value
-	}
-
-	if !plan.IsEcrImage.IsNull() && !plan.IsEcrImage.IsUnknown() {
-		params["is_ecr_image"] = plan.IsEcrImage.Value
-	}
-
-	if !plan.WaitForSteadyState.IsNull() && !plan.WaitForSteadyState.IsUnknown() {
-		params["wait_for_steady_state"] = plan.WaitForSteadyState.Value
-	}
-
-	input := cac.AssetInput{
-		Asset:           client.CompileAsset("aws", "ecs_web_service", assetutil.DefaultAssetVersion),
-		AssetVersion:    assetutil.DefaultAssetVersion,
-		AssetParameters: params,
+	// TODO HACK: https://aptible.slack.com/archives/C03C2STPTDX/p1664478414991299
+	dd := strings.SplitN(plan.LbCertDomain.Value, ".", 2)
+	if len(dd) != 2 {
+		return fmt.Errorf("lb_cert_domain %q is not a subdomain", plan.LbCertDomain.Value)
 	}
-
+	input.AssetParameters["lb_cert_domain"] = dd[1]
+	input.AssetParameters["lb_cert_subdomain"] = dd[0]
 	if !plan.ConnectsTo.IsNull() && !plan.ConnectsTo.IsUnknown() {
-		connect := []string{}
-		_ = plan.ConnectsTo.ElementsAs(ctx, &connect, false)
-		input.ConnectsTo = connect
+		input.ConnectsTo = util.StringsFromList(ctx, plan.ConnectsTo)
 	}
 
-	return input, nil
+	return nil
 }
 
-func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
-	cmd := []types.String{}
-	cmdList := output.CurrentAssetParameters.Data["container_command"].([]interface{})
-	for _, c := range cmdList {
-		cmd = append(cmd, types.String{Value: c.(string)})
-	}
-
-	/* connect := []attr.Value{}
-	for _, c := range output.ConnectsTo {
-		connect = append(connect, types.String{Value: c})
-	}
-	connectsTo := types.List{Elems: connect, ElemType: types.StringType}
-	if len(connect) == 0 {
-		connectsTo.Null = true
-	} */
+// customAssetOutputToPlan - read back connects_to and environment_secrets, and lb_cert_domain from its parts
+func customAssetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput, model *ResourceModel) error {
 	// TODO: HACK we are not keeping what the API sends us because the API changes the
 	// order which causes terraform to error
 	connectsTo := plan.ConnectsTo
+	if connectsTo.ElemType == nil {
+		// nothing planned (e.g. on import), the API is the only source we have
+		connect := []attr.Value{}
+		for _, c := range output.ConnectsTo {
+			connect = append(connect, types.String{Value: c})
+		}
+		connectsTo = types.List{Elems: connect, ElemType: types.StringType}
+		if len(connect) == 0 {
+			connectsTo.Null = true
+		}
+	}
+	model.ConnectsTo = connectsTo
 
 	// TODO: figure out how to not need an intermediate struct for marshal/unmarshal
 	secretsJson := []EnvJson{}
 	bts, err := json.Marshal(output.CurrentAssetParameters.Data["environment_secrets"])
 	if err != nil {
-		return nil, err
+		return err
 	}
 	err = json.Unmarshal(bts, &secretsJson)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	secrets := map[string]Env{}
@@ -249,39 +135,13 @@ func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.Asse
 			SecretJsonKey: types.String{Value: v.SecretJsonKey},
 		}
 	}
-
-	port := output.CurrentAssetParameters.Data["container_port"].(float64)
+	model.EnvironmentSecrets = secrets
 
 	// TODO: HACK
-	domain := fmt.Sprintf("%s.%s",
-		output.CurrentAssetParameters.Data["lb_cert_subdomain"].(string),
-		output.CurrentAssetParameters.Data["lb_cert_domain"].(string),
-	)
-
-	outputs := *output.Outputs
-
-	model := &ResourceModel{
-		Id:                         types.String{Value: output.Id},
-		AssetVersion:               types.String{Value: output.AssetVersion},
-		EnvironmentId:              types.String{Value: output.Environment.Id},
-		OrganizationId:             types.String{Value: output.Environment.Organization.Id},
-		Status:                     types.String{Value: string(output.Status)},
-		VpcName:                    types.String{Value: output.CurrentAssetParameters.Data["vpc_name"].(string)},
-		Name:                       types.String{Value: output.CurrentAssetParameters.Data["name"].(string)},
-		LbCertArn:                  types.String{Value: output.CurrentAssetParameters.Data["lb_cert_arn"].(string)},
-		LbCertDomain:               types.String{Value: domain},
-		IsPublic:                   types.Bool{Value: output.CurrentAssetParameters.Data["is_public"].(bool)},
-		ContainerName:              types.String{Value: output.CurrentAssetParameters.Data["container_name"].(string)},
-		ContainerPort:              types.Number{Value: big.NewFloat(port)},
-		ContainerImage:             types.String{Value: output.CurrentAssetParameters.Data["container_image"].(string)},
-		ContainerRegistrySecretArn: util.StringVal(output.CurrentAssetParameters.Data["container_registry_secret_arn"]),
-		LoadBalancerUrl:            util.StringVal(outputs["load_balancer_url"].Data),
-		ConnectsTo:                 connectsTo,
-		ContainerCommand:           cmd,
-		EnvironmentSecrets:         secrets,
-		IsEcrImage:                 util.BoolVal(output.CurrentAssetParameters.Data["is_ecr_image"]),
-		WaitForSteadyState:         util.BoolVal(output.CurrentAssetParameters.Data["wait_for_steady_state"]),
-	}
+	model.LbCertDomain = types.String{Value: fmt.Sprintf("%s.%s",
+		util.SafeString(output.CurrentAssetParameters.Data["lb_cert_subdomain"]),
+		util.SafeString(output.CurrentAssetParameters.Data["lb_cert_domain"]),
+	)}
 
-	return model, nil
+	return nil
 }
@@ -0,0 +1,105 @@
+package ecsweb
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:                         types.String{Unknown: true},
+		AssetVersion:               types.String{Unknown: true},
+		Status:                     types.String{Unknown: true},
+		EnvironmentId:              types.String{Value: env.Id},
+		OrganizationId:             types.String{Value: env.Organization.Id},
+		VpcName:                    types.String{Value: "network"},
+		Name:                       types.String{Value: "web"},
+		IsPublic:                   types.Bool{Value: true},
+		IsEcrImage:                 types.Bool{Null: true},
+		ContainerName:              types.String{Value: "nginx"},
+		ContainerPort:              types.Number{Value: big.NewFloat(80)},
+		ContainerImage:             types.String{Value: "nginx:alpine"},
+		ContainerCommand:           []types.String{{Value: "nginx"}, {Value: "-g"}, {Value: "daemon off;"}},
+		ContainerRegistrySecretArn: types.String{Null: true},
+		EnvironmentSecrets: map[string]Env{
+			"DATABASE_URL": {
+				SecretArn:     types.String{Value: "arn:aws:secretsmanager:us-east-1:000000000000:secret/db"},
+				SecretJsonKey: types.String{Value: "uri"},
+			},
+		},
+		LbCertArn:          types.String{Value: "arn:aws:acm:us-east-1:000000000000:certificate/abc"},
+		LbCertDomain:       types.String{Value: "www.example.com"},
+		ConnectsTo:         types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "db-asset"}}},
+		LoadBalancerUrl:    types.String{Unknown: true},
+		WaitForSteadyState: types.Bool{Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "www.example.com", state.LbCertDomain.Value)
+	assert.Equal(t, 0, big.NewFloat(80).Cmp(state.ContainerPort.Value))
+	assert.Equal(t, plan.ContainerCommand, state.ContainerCommand)
+	assert.Equal(t, plan.EnvironmentSecrets, state.EnvironmentSecrets)
+	assert.Equal(t, plan.ConnectsTo, state.ConnectsTo)
+	assert.Contains(t, state.LoadBalancerUrl.Value, "elb.amazonaws.com")
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, "www", asset.CurrentAssetParameters.Data["lb_cert_subdomain"])
+	assert.Equal(t, "example.com", asset.CurrentAssetParameters.Data["lb_cert_domain"])
+	assert.Equal(t, []string{"db-asset"}, asset.ConnectsTo)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state.ContainerImage, refreshed.ContainerImage)
+	assert.Equal(t, state.EnvironmentSecrets, refreshed.EnvironmentSecrets)
+
+	planned := state
+	planned.ContainerImage = types.String{Value: "nginx:1.23-alpine"}
+	planned.WaitForSteadyState = types.Bool{Value: true}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "nginx:1.23-alpine", state.ContainerImage.Value)
+	assert.True(t, state.WaitForSteadyState.Value)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state.Id, importedState.Id)
+	assert.Equal(t, state.ContainerImage, importedState.ContainerImage)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err = c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
+
+func TestResourceValidators(t *testing.T) {
+	r := NewResource()
+
+	assert.False(t, assettest.ValidateAttribute(t, r, "lb_cert_domain", types.String{Value: "www.example.com"}).HasError())
+	// the first label is sent as lb_cert_subdomain, the api can't take a wildcard there
+	assert.True(t, assettest.ValidateAttribute(t, r, "lb_cert_domain", types.String{Value: "*.example.com"}).HasError())
+}
@@ -0,0 +1,69 @@
+package rds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestDataSourceByName(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	created := assettest.Create(t, r, ResourceModel{
+		Id:               types.String{Unknown: true},
+		AssetVersion:     types.String{Unknown: true},
+		Status:           types.String{Unknown: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		VpcName:          types.String{Value: "network"},
+		Name:             types.String{Value: "db"},
+		Engine:           types.String{Value: "postgres"},
+		EngineVersion:    types.String{Value: "14"},
+		UriSecretArn:     types.String{Unknown: true},
+		SecretsKmsKeyArn: types.String{Unknown: true},
+		DBIdentifier:     types.String{Unknown: true},
+	})
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	resp := assettest.ReadDataSourceAttributes(t, d, map[string]string{
+		"organization_id": env.Organization.Id,
+		"environment_id":  env.Id,
+		"name":            "db",
+	})
+	assettest.RequireNoError(t, resp.Diagnostics)
+
+	// every attribute of the resource but its timeouts is exposed
+	for name, expected := range map[string]types.String{
+		"id":                  state.Id,
+		"status":              state.Status,
+		"engine":              state.Engine,
+		"engine_version":      state.EngineVersion,
+		"uri_secret_arn":      state.UriSecretArn,
+		"secrets_kms_key_arn": state.SecretsKmsKeyArn,
+		"db_identifier":       state.DBIdentifier,
+	} {
+		var actual types.String
+		assettest.RequireNoError(t, resp.State.GetAttribute(context.Background(), path.Root(name), &actual))
+		assert.Equal(t, expected, actual, name)
+	}
+
+	missing := assettest.ReadDataSourceAttributes(t, d, map[string]string{
+		"organization_id": env.Organization.Id,
+		"environment_id":  env.Id,
+		"name":            "missing",
+	})
+	assert.True(t, missing.Diagnostics.HasError())
+	assert.Contains(t, missing.Diagnostics[0].Detail(), `no rds with name "missing"`)
+}
@@ -0,0 +1,257 @@
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:               types.String{Unknown: true},
+		AssetVersion:     types.String{Unknown: true},
+		Status:           types.String{Unknown: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		VpcName:          types.String{Value: "network"},
+		Name:             types.String{Value: "db"},
+		Engine:           types.String{Value: "postgres"},
+		EngineVersion:    types.String{Value: "14"},
+		UriSecretArn:     types.String{Unknown: true},
+		SecretsKmsKeyArn: types.String{Unknown: true},
+		DBIdentifier:     types.String{Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "postgres", state.Engine.Value)
+	assert.Contains(t, state.UriSecretArn.Value, "arn:aws:secretsmanager")
+	assert.Contains(t, state.SecretsKmsKeyArn.Value, "arn:aws:kms")
+	assert.Equal(t, "db-"+state.Id.Value, state.DBIdentifier.Value)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.EngineVersion = types.String{Value: "15"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "15", state.EngineVersion.Value)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
+
+func TestResourceTimeouts(t *testing.T) {
+	c, env := assettest.Setup(t)
+	c.SettleAfter = time.Hour
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:               types.String{Unknown: true},
+		AssetVersion:     types.String{Unknown: true},
+		Status:           types.String{Unknown: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		VpcName:          types.String{Value: "network"},
+		Name:             types.String{Value: "db"},
+		Engine:           types.String{Value: "postgres"},
+		EngineVersion:    types.String{Value: "14"},
+		UriSecretArn:     types.String{Unknown: true},
+		SecretsKmsKeyArn: types.String{Unknown: true},
+		DBIdentifier:     types.String{Unknown: true},
+		Timeouts: &assetutil.Timeouts{
+			Create: types.String{Value: "20ms"},
+			Update: types.String{Null: true},
+			Delete: types.String{Null: true},
+		},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assert.True(t, created.Diagnostics.HasError())
+	assert.Contains(t, created.Diagnostics[0].Detail(), "timed out")
+
+	// the asset was created, its id is kept in state so the next apply can pick it up
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.Id.Value)
+	assert.Equal(t, plan.Timeouts, state.Timeouts)
+}
+
+func TestResourceProviderDefaults(t *testing.T) {
+	r := NewResource()
+	config := ResourceModel{
+		Id:               types.String{Null: true},
+		AssetVersion:     types.String{Null: true},
+		Status:           types.String{Null: true},
+		EnvironmentId:    types.String{Null: true},
+		OrganizationId:   types.String{Null: true},
+		VpcName:          types.String{Value: "network"},
+		Name:             types.String{Value: "db"},
+		Engine:           types.String{Value: "postgres"},
+		EngineVersion:    types.String{Value: "14"},
+		UriSecretArn:     types.String{Null: true},
+		SecretsKmsKeyArn: types.String{Null: true},
+		DBIdentifier:     types.String{Null: true},
+	}
+	planned := config
+	planned.Id = types.String{Unknown: true}
+	planned.AssetVersion = types.String{Unknown: true}
+	planned.Status = types.String{Unknown: true}
+	planned.EnvironmentId = types.String{Unknown: true}
+	planned.OrganizationId = types.String{Unknown: true}
+	planned.UriSecretArn = types.String{Unknown: true}
+	planned.SecretsKmsKeyArn = types.String{Unknown: true}
+	planned.DBIdentifier = types.String{Unknown: true}
+
+	assettest.ConfigureProvider(t, r, &util.ProviderData{OrganizationId: "org", EnvironmentId: "env"})
+	resp := assettest.ModifyPlan(t, r, config, planned)
+	assettest.RequireNoError(t, resp.Diagnostics)
+	var plan ResourceModel
+	assettest.Get(t, tfsdk.State(resp.Plan), &plan)
+	assert.Equal(t, "org", plan.OrganizationId.Value)
+	assert.Equal(t, "env", plan.EnvironmentId.Value)
+
+	// ids set on the resource win
+	config.EnvironmentId = types.String{Value: "other-env"}
+	planned.EnvironmentId = config.EnvironmentId
+	resp = assettest.ModifyPlan(t, r, config, planned)
+	assettest.RequireNoError(t, resp.Diagnostics)
+	assettest.Get(t, tfsdk.State(resp.Plan), &plan)
+	assert.Equal(t, "other-env", plan.EnvironmentId.Value)
+
+	// neither the resource nor the provider sets the organization
+	assettest.ConfigureProvider(t, r, &util.ProviderData{EnvironmentId: "env"})
+	resp = assettest.ModifyPlan(t, r, config, planned)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "APTIBLE_ORGANIZATION_ID")
+}
+
+func TestResourceAssetVersion(t *testing.T) {
+	c, env := assettest.Setup(t)
+	c.Bundles = []cac.AssetBundle{
+		{Identifier: client.CompileAsset("aws", "rds", "latest"), Name: "rds", Types: []string{"latest", "v1", "v2"}},
+	}
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	config := ResourceModel{
+		Id:               types.String{Null: true},
+		AssetVersion:     types.String{Value: "v3"},
+		Status:           types.String{Null: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		VpcName:          types.String{Value: "network"},
+		Name:             types.String{Value: "db"},
+		Engine:           types.String{Value: "postgres"},
+		EngineVersion:    types.String{Value: "14"},
+		UriSecretArn:     types.String{Null: true},
+		SecretsKmsKeyArn: types.String{Null: true},
+		DBIdentifier:     types.String{Null: true},
+	}
+	plan := config
+	plan.Id = types.String{Unknown: true}
+	plan.Status = types.String{Unknown: true}
+	plan.UriSecretArn = types.String{Unknown: true}
+	plan.SecretsKmsKeyArn = types.String{Unknown: true}
+	plan.DBIdentifier = types.String{Unknown: true}
+
+	// a version the environment doesn't allow fails while planning
+	resp := assettest.ModifyPlan(t, r, config, plan)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `version "v3" of the aws rds bundle is not available`)
+
+	config.AssetVersion = types.String{Value: "v1"}
+	plan.AssetVersion = config.AssetVersion
+	resp = assettest.ModifyPlan(t, r, config, plan)
+	assettest.RequireNoError(t, resp.Diagnostics)
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, "v1", state.AssetVersion.Value)
+
+	// changing the version upgrades the same asset
+	planned := state
+	planned.AssetVersion = types.String{Value: "v2"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	var upgraded ResourceModel
+	assettest.Get(t, updated.State, &upgraded)
+	assert.Equal(t, state.Id, upgraded.Id)
+	assert.Equal(t, "v2", upgraded.AssetVersion.Value)
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, client.CompileAsset("aws", "rds", "v2"), asset.Asset)
+
+	// a version moved outside of terraform is read back so the pinned one shows as a diff
+	_, err = c.UpdateAsset(context.Background(), state.Id.Value, env.Id, env.Organization.Id, cac.AssetInput{
+		Asset:           client.CompileAsset("aws", "rds", "latest"),
+		AssetVersion:    "latest",
+		AssetParameters: asset.CurrentAssetParameters.Data,
+	})
+	assert.Nil(t, err)
+	read := assettest.Read(t, r, upgraded)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, "latest", refreshed.AssetVersion.Value)
+}
+
+func TestResourceImmutableAttributes(t *testing.T) {
+	schema := assettest.Schema(t, NewResource())
+
+	for _, name := range []string{"organization_id", "environment_id", "vpc_name", "name", "engine"} {
+		assert.Contains(t, schema.Attributes[name].PlanModifiers, resource.RequiresReplace(), name)
+	}
+	// engine versions and the asset bundle are upgraded in place
+	for _, name := range []string{"engine_version", "asset_version"} {
+		assert.NotContains(t, schema.Attributes[name].PlanModifiers, resource.RequiresReplace(), name)
+	}
+}
+
+func TestResourceValidators(t *testing.T) {
+	r := NewResource()
+
+	for _, engine := range []string{"postgres", "mysql", "mariadb"} {
+		assert.False(t, assettest.ValidateAttribute(t, r, "engine", types.String{Value: engine}).HasError(), engine)
+	}
+	diags := assettest.ValidateAttribute(t, r, "engine", types.String{Value: "oracle"})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), `got: "oracle"`)
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:                   types.String{Unknown: true},
+		AssetVersion:         types.String{Unknown: true},
+		Status:               types.String{Unknown: true},
+		EnvironmentId:        types.String{Value: env.Id},
+		OrganizationId:       types.String{Value: env.Organization.Id},
+		VpcName:              types.String{Value: "network"},
+		Name:                 types.String{Value: "cache"},
+		Description:          types.String{Value: "a cache"},
+		SnapshotWindow:       types.String{Value: "04:00-05:00"},
+		MaintenanceWindow:    types.String{Value: "sun:05:00-sun:06:00"},
+		UriSecretArn:         types.String{Unknown: true},
+		SecretsKmsKeyArn:     types.String{Unknown: true},
+		ElasticacheARN:       types.String{Unknown: true},
+		ElasticacheClusterId: types.String{Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "sun:05:00-sun:06:00", state.MaintenanceWindow.Value)
+	assert.Contains(t, state.ElasticacheARN.Value, "arn:aws:elasticache")
+	assert.Equal(t, "redis-"+state.Id.Value, state.ElasticacheClusterId.Value)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.SnapshotWindow = types.String{Value: "02:00-03:00"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "02:00-03:00", state.SnapshotWindow.Value)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
+
+func TestResourceValidators(t *testing.T) {
+	r := NewResource()
+
+	assert.False(t, assettest.ValidateAttribute(t, r, "snapshot_window", types.String{Value: "04:00-05:00"}).HasError())
+	assert.True(t, assettest.ValidateAttribute(t, r, "snapshot_window", types.String{Value: "4am-5am"}).HasError())
+	assert.False(t, assettest.ValidateAttribute(t, r, "maintenance_window", types.String{Value: "sun:05:00-sun:06:00"}).HasError())
+	assert.True(t, assettest.ValidateAttribute(t, r, "maintenance_window", types.String{Value: "05:00-06:00"}).HasError())
+	assert.True(t, assettest.ValidateAttribute(t, r, "name", types.String{Value: ""}).HasError())
+}
@@ -1,106 +1,37 @@
 package secret
 
+//go:generate go run ../../assetgen
+
 import (
-	"context"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 
-	cac "github.com/aptible/cloud-api-clients/clients/go"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset"
 	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
 )
 
 var resourceTypeName = "_aws_secret"
 var resourceDescription = "Secret manager resource"
 
-// TODO - autogenerated
-type ResourceModel struct {
-	Id             types.String `tfsdk:"id" json:"id"`
-	AssetVersion   types.String `tfsdk:"asset_version" json:"asset_version"`
-	EnvironmentId  types.String `tfsdk:"environment_id" json:"environment_id"`
-	OrganizationId types.String `tfsdk:"organization_id" json:"organization_id"`
-	Status         types.String `tfsdk:"status" json:"status"`
+var immutableAttributes = []string{"organization_id", "environment_id", "name"}
 
-	Name         types.String `tfsdk:"name"`
-	SecretString types.String `tfsdk:"secret_string"`
-	Arn          types.String `tfsdk:"arn"`
-	KmsArn       types.String `tfsdk:"kms_arn"`
+var resourceTimeouts = assetutil.TimeoutDefaults{
+	Create: 5 * time.Minute,
+	Update: 5 * time.Minute,
+	Delete: 10 * time.Minute,
 }
 
-var AssetSchema = map[string]tfsdk.Attribute{
-	"id": {
-		Description: "A valid asset id",
-		Type:        types.StringType,
-		Computed:    true,
-	},
-	"status": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-
-	"environment_id": {
-		Description: "A valid environment id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"organization_id": {
-		Description: "A valid organization id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"asset_version": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-	"name": {
-		Type:     types.StringType,
-		Required: true,
-	},
-	"secret_string": {
-		Description: "A secret",
-		Type:        types.StringType,
-		Required:    true,
-		Sensitive:   true,
-	},
-	"arn": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-	"kms_arn": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-}
-
-func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
-	input := cac.AssetInput{
-		Asset:        client.CompileAsset("aws", "secret_manager", assetutil.DefaultAssetVersion),
-		AssetVersion: assetutil.DefaultAssetVersion,
-		AssetParameters: map[string]interface{}{
-			"name":          plan.Name.Value,
-			"secret_string": plan.SecretString.Value,
-		},
-	}
-
-	return input, nil
-}
-
-func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
-	outputs := *output.Outputs
-
-	model := &ResourceModel{
-		Id:             types.String{Value: output.Id},
-		AssetVersion:   types.String{Value: output.AssetVersion},
-		EnvironmentId:  types.String{Value: output.Environment.Id},
-		OrganizationId: types.String{Value: output.Environment.Organization.Id},
-		Status:         types.String{Value: string(output.Status)},
-		Name:           types.String{Value: output.CurrentAssetParameters.Data["name"].(string)},
-		SecretString:   types.String{Value: output.CurrentAssetParameters.Data["secret_string"].(string)},
-		Arn:            types.String{Value: util.SafeString(outputs["secret_arn"].Data)},
-		KmsArn:         types.String{Value: util.SafeString(outputs["kms_arn"].Data)},
-	}
-
-	return model, nil
+func NewResource() resource.Resource {
+	return asset.NewResource(asset.ResourceConfig[ResourceModel]{
+		TypeName:            resourceTypeName,
+		Description:         resourceDescription,
+		Platform:            "aws",
+		AssetType:           resourceAssetType,
+		Schema:              AssetSchema,
+		ImmutableAttributes: immutableAttributes,
+		Timeouts:            resourceTimeouts,
+		ToAssetInput:        planToAssetInput,
+		ToState:             assetOutputToPlan,
+	})
 }
@@ -0,0 +1,66 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:             types.String{Unknown: true},
+		AssetVersion:   types.String{Unknown: true},
+		Status:         types.String{Unknown: true},
+		EnvironmentId:  types.String{Value: env.Id},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		Name:           types.String{Value: "api-key"},
+		SecretString:   types.String{Value: "hunter2"},
+		Arn:            types.String{Unknown: true},
+		KmsArn:         types.String{Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "hunter2", state.SecretString.Value)
+	assert.Contains(t, state.Arn.Value, "arn:aws:secretsmanager")
+	assert.Contains(t, state.KmsArn.Value, "arn:aws:kms")
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.SecretString = types.String{Value: "correct-horse-battery-staple"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "correct-horse-battery-staple", state.SecretString.Value)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
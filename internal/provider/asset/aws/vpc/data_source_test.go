@@ -0,0 +1,46 @@
+package vpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestDataSourceByName(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	created := assettest.Create(t, r, ResourceModel{
+		Id:             types.String{Unknown: true},
+		AssetVersion:   types.String{Unknown: true},
+		Status:         types.String{Unknown: true},
+		EnvironmentId:  types.String{Value: env.Id},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		Name:           types.String{Value: "network"},
+	})
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	resp := assettest.ReadDataSourceAttributes(t, d, map[string]string{
+		"organization_id": env.Organization.Id,
+		"environment_id":  env.Id,
+		"name":            "network",
+	})
+	assettest.RequireNoError(t, resp.Diagnostics)
+
+	var id, version types.String
+	assettest.RequireNoError(t, resp.State.GetAttribute(context.Background(), path.Root("id"), &id))
+	assettest.RequireNoError(t, resp.State.GetAttribute(context.Background(), path.Root("asset_version"), &version))
+	assert.Equal(t, state.Id, id)
+	assert.Equal(t, state.AssetVersion, version)
+}
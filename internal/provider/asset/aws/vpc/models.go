@@ -1,82 +1,37 @@
 package vpc
 
+//go:generate go run ../../assetgen
+
 import (
-	"context"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 
-	cac "github.com/aptible/cloud-api-clients/clients/go"
-	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset"
 	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
 )
 
 var resourceTypeName = "_aws_vpc"
 var resourceDescription = "VPC resource"
 
-// TODO - autogenerated
-type ResourceModel struct {
-	Id             types.String `tfsdk:"id" json:"id"`
-	AssetVersion   types.String `tfsdk:"asset_version" json:"asset_version"`
-	EnvironmentId  types.String `tfsdk:"environment_id" json:"environment_id"`
-	OrganizationId types.String `tfsdk:"organization_id" json:"organization_id"`
-	Status         types.String `tfsdk:"status" json:"status"`
-
-	Name types.String `tfsdk:"name" json:"name"`
-}
-
-var AssetSchema = map[string]tfsdk.Attribute{
-	"id": {
-		Description: "A valid asset id",
-		Type:        types.StringType,
-		Computed:    true,
-	},
-	"status": {
-		Type:     types.StringType,
-		Computed: true,
-	},
+var immutableAttributes = []string{"organization_id", "environment_id", "name"}
 
-	"environment_id": {
-		Description: "A valid environment id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"organization_id": {
-		Description: "A valid organization id",
-		Type:        types.StringType,
-		Required:    true,
-	},
-	"asset_version": {
-		Type:     types.StringType,
-		Computed: true,
-	},
-	"name": {
-		Type:     types.StringType,
-		Required: true,
-	},
+var resourceTimeouts = assetutil.TimeoutDefaults{
+	Create: 20 * time.Minute,
+	Update: 20 * time.Minute,
+	Delete: 20 * time.Minute,
 }
 
-func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
-	input := cac.AssetInput{
-		Asset:        client.CompileAsset("aws", "vpc", assetutil.DefaultAssetVersion),
-		AssetVersion: assetutil.DefaultAssetVersion,
-		AssetParameters: map[string]interface{}{
-			"name": plan.Name.Value,
-		},
-	}
-
-	return input, nil
-}
-
-func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
-	vpc := &ResourceModel{
-		Id:             types.String{Value: output.Id},
-		AssetVersion:   types.String{Value: output.AssetVersion},
-		EnvironmentId:  types.String{Value: output.Environment.Id},
-		OrganizationId: types.String{Value: output.Environment.Organization.Id},
-		Status:         types.String{Value: string(output.Status)},
-		Name:           types.String{Value: output.CurrentAssetParameters.Data["name"].(string)},
-	}
-
-	return vpc, nil
+func NewResource() resource.Resource {
+	return asset.NewResource(asset.ResourceConfig[ResourceModel]{
+		TypeName:            resourceTypeName,
+		Description:         resourceDescription,
+		Platform:            "aws",
+		AssetType:           resourceAssetType,
+		Schema:              AssetSchema,
+		ImmutableAttributes: immutableAttributes,
+		Timeouts:            resourceTimeouts,
+		ToAssetInput:        planToAssetInput,
+		ToState:             assetOutputToPlan,
+	})
 }
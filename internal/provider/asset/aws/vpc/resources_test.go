@@ -0,0 +1,107 @@
+package vpc
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fake"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:             types.String{Unknown: true},
+		AssetVersion:   types.String{Unknown: true},
+		Status:         types.String{Unknown: true},
+		EnvironmentId:  types.String{Value: env.Id},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		Name:           types.String{Value: "network"},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.Id.Value)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "latest", state.AssetVersion.Value)
+	assert.Equal(t, "network", state.Name.Value)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.Name = types.String{Value: "network-renamed"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "network-renamed", state.Name.Value)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, asset.Status)
+}
+
+func TestResourceImportStateRejectsMalformedId(t *testing.T) {
+	c, _ := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	imported := assettest.ImportState(t, r, "not,a-valid,id")
+	assert.True(t, imported.Diagnostics.HasError())
+}
+
+func TestResourceReadRemovesVanishedAsset(t *testing.T) {
+	for name, vanish := range map[string]func(c *fake.Client, id string){
+		"deleted out of band": func(c *fake.Client, id string) { c.RemoveAsset(id) },
+		"destroyed": func(c *fake.Client, id string) {
+			c.SetAssetStatus(id, cac.ASSETSTATUS_DESTROYED)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, env := assettest.Setup(t)
+			r := NewResource()
+			assettest.Configure(t, r, c)
+
+			created := assettest.Create(t, r, ResourceModel{
+				Id:             types.String{Unknown: true},
+				AssetVersion:   types.String{Unknown: true},
+				Status:         types.String{Unknown: true},
+				EnvironmentId:  types.String{Value: env.Id},
+				OrganizationId: types.String{Value: env.Organization.Id},
+				Name:           types.String{Value: "network"},
+			})
+			assettest.RequireNoError(t, created.Diagnostics)
+			var state ResourceModel
+			assettest.Get(t, created.State, &state)
+
+			vanish(c, state.Id.Value)
+
+			read := assettest.Read(t, r, state)
+			assettest.RequireNoError(t, read.Diagnostics)
+			assert.True(t, assettest.IsRemoved(read.State))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package bundles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &BundlesDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &BundlesDataSource{}
+}
+
+type BundlesDataSource struct {
+	client client.CloudClient
+}
+
+func (r BundlesDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "The asset bundles an environment is allowed to use, e.g. to check " +
+			"`contains(data.aptible_asset_bundles.env.identifiers, \"aws__rds__latest\")` before creating an asset",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"environment_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"organization_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"identifiers": {
+				Description: "Identifier of every allowed bundle version, e.g. aws__rds__latest",
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+			"bundles": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"identifier": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"description": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"asset_platform": {
+						Description: "Platform of the bundle, e.g. aws",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"asset_type": {
+						Description: "Type of the bundle, e.g. rds",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"versions": {
+						Description: "Versions of the bundle that can be used as asset_version",
+						Type:        types.ListType{ElemType: types.StringType},
+						Computed:    true,
+					},
+					"actions": {
+						Description: "Names of the actions the bundle supports",
+						Type:        types.ListType{ElemType: types.StringType},
+						Computed:    true,
+					},
+					"parameters_schema": {
+						Description: "Json schema of the parameters the bundle accepts",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *BundlesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_bundles"
+}
+
+func (r *BundlesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *BundlesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bundles, err := r.client.ListAssetBundles(ctx, config.OrganizationId.Value, config.EnvironmentId.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving asset bundles",
+			err.Error(),
+		)
+		return
+	}
+
+	state := DataSourceModel{
+		ID:             config.EnvironmentId,
+		OrganizationId: config.OrganizationId,
+		EnvironmentId:  config.EnvironmentId,
+		Identifiers:    stringList(identifiers(bundles)),
+		Bundles:        []Bundle{},
+	}
+	for _, bundle := range bundles {
+		model, err := bundleToModel(bundle)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error retrieving asset bundles",
+				fmt.Sprintf("Could not read the parameters schema of bundle %s: %s", bundle.Identifier, err.Error()),
+			)
+			return
+		}
+		state.Bundles = append(state.Bundles, model)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,74 @@
+package bundles
+
+import (
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestDataSourceRead(t *testing.T) {
+	c, env := assettest.Setup(t)
+	description := "a postgres database"
+	c.Bundles = []cac.AssetBundle{
+		{
+			Identifier:     "aws__rds__latest",
+			Name:           "rds",
+			Description:    &description,
+			Types:          []string{"latest", "v2"},
+			Actions:        map[string]cac.AssetAction{"restart": {Name: "restart"}, "backup": {Name: "backup"}},
+			UserParameters: map[string]interface{}{"type": "object"},
+		},
+		{
+			Identifier: "aws__vpc__latest",
+			Name:       "vpc",
+			Types:      []string{"latest"},
+			Actions:    map[string]cac.AssetAction{},
+		},
+	}
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := assettest.ReadDataSource(t, d, DataSourceModel{
+		ID:             types.String{Null: true},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		EnvironmentId:  types.String{Value: env.Id},
+		Identifiers:    types.List{ElemType: types.StringType, Null: true},
+	})
+	assettest.RequireNoError(t, read.Diagnostics)
+
+	var state DataSourceModel
+	assettest.Get(t, read.State, &state)
+	assert.Equal(t, env.Id, state.ID.Value)
+	assert.Equal(t, stringList([]string{"aws__rds__latest", "aws__rds__v2", "aws__vpc__latest"}), state.Identifiers)
+	assert.Len(t, state.Bundles, 2)
+
+	rds := state.Bundles[0]
+	assert.Equal(t, "aws", rds.AssetPlatform.Value)
+	assert.Equal(t, "rds", rds.AssetType.Value)
+	assert.Equal(t, description, rds.Description.Value)
+	assert.Equal(t, stringList([]string{"latest", "v2"}), rds.Versions)
+	assert.Equal(t, stringList([]string{"backup", "restart"}), rds.Actions)
+	assert.Equal(t, `{"type":"object"}`, rds.ParametersSchema.Value)
+
+	vpc := state.Bundles[1]
+	assert.True(t, vpc.Description.Null)
+	assert.True(t, vpc.ParametersSchema.Null)
+}
+
+func TestDataSourceReadUnknownEnvironment(t *testing.T) {
+	c, env := assettest.Setup(t)
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := assettest.ReadDataSource(t, d, DataSourceModel{
+		ID:             types.String{Null: true},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		EnvironmentId:  types.String{Value: "missing"},
+		Identifiers:    types.List{ElemType: types.StringType, Null: true},
+	})
+	assert.True(t, read.Diagnostics.HasError())
+}
@@ -0,0 +1,85 @@
+package bundles
+
+import (
+	"encoding/json"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+type DataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	EnvironmentId  types.String `tfsdk:"environment_id"`
+	Identifiers    types.List   `tfsdk:"identifiers"`
+	Bundles        []Bundle     `tfsdk:"bundles"`
+}
+
+// Bundle - an asset bundle allowed in the environment
+type Bundle struct {
+	Identifier       types.String `tfsdk:"identifier"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	AssetPlatform    types.String `tfsdk:"asset_platform"`
+	AssetType        types.String `tfsdk:"asset_type"`
+	Versions         types.List   `tfsdk:"versions"`
+	Actions          types.List   `tfsdk:"actions"`
+	ParametersSchema types.String `tfsdk:"parameters_schema"`
+}
+
+func bundleToModel(bundle cac.AssetBundle) (Bundle, error) {
+	platform, assetType, _ := client.SplitAsset(bundle.Identifier)
+	actions := maps.Keys(bundle.Actions)
+	slices.Sort(actions)
+
+	model := Bundle{
+		Identifier:       types.String{Value: bundle.Identifier},
+		Name:             types.String{Value: bundle.Name},
+		Description:      types.String{Null: true},
+		AssetPlatform:    types.String{Value: platform},
+		AssetType:        types.String{Value: assetType},
+		Versions:         stringList(bundle.Types),
+		Actions:          stringList(actions),
+		ParametersSchema: types.String{Null: true},
+	}
+	if bundle.Description != nil {
+		model.Description = types.String{Value: *bundle.Description}
+	}
+	if bundle.UserParameters != nil {
+		schema, err := json.Marshal(bundle.UserParameters)
+		if err != nil {
+			return Bundle{}, err
+		}
+		model.ParametersSchema = types.String{Value: string(schema)}
+	}
+	return model, nil
+}
+
+// identifiers - the identifier of every version of every bundle, e.g. aws__rds__latest
+func identifiers(bundles []cac.AssetBundle) []string {
+	ids := []string{}
+	for _, bundle := range bundles {
+		platform, assetType, _ := client.SplitAsset(bundle.Identifier)
+		for _, version := range bundle.Types {
+			id := client.CompileAsset(platform, assetType, version)
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func stringList(values []string) types.List {
+	list := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, value := range values {
+		list.Elems = append(list.Elems, types.String{Value: value})
+	}
+	return list
+}
@@ -0,0 +1,223 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AssetsDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &AssetsDataSource{}
+}
+
+type AssetsDataSource struct {
+	client client.CloudClient
+}
+
+type AssetsDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	EnvironmentId  types.String `tfsdk:"environment_id"`
+	AssetPlatform  types.String `tfsdk:"asset_platform"`
+	AssetType      types.String `tfsdk:"asset_type"`
+	Name           types.String `tfsdk:"name"`
+	Status         types.String `tfsdk:"status"`
+	Assets         []AssetModel `tfsdk:"assets"`
+}
+
+// AssetModel - an asset matching the filters of the aptible_assets data source
+type AssetModel struct {
+	Id               types.String `tfsdk:"id"`
+	AssetPlatform    types.String `tfsdk:"asset_platform"`
+	AssetType        types.String `tfsdk:"asset_type"`
+	AssetVersion     types.String `tfsdk:"asset_version"`
+	Name             types.String `tfsdk:"name"`
+	Status           types.String `tfsdk:"status"`
+	Parameters       types.String `tfsdk:"parameters"`
+	Outputs          types.Map    `tfsdk:"outputs"`
+	SensitiveOutputs types.Map    `tfsdk:"sensitive_outputs"`
+}
+
+func (r AssetsDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	filter := func(description string) tfsdk.Attribute {
+		return tfsdk.Attribute{
+			Description: description,
+			Type:        types.StringType,
+			Optional:    true,
+		}
+	}
+	computed := func(t attr.Type, description string) tfsdk.Attribute {
+		return tfsdk.Attribute{
+			Description: description,
+			Type:        t,
+			Computed:    true,
+		}
+	}
+
+	sensitiveOutputs := computed(types.MapType{ElemType: types.StringType}, "Outputs flagged sensitive by the bundle")
+	sensitiveOutputs.Sensitive = true
+
+	return tfsdk.Schema{
+		MarkdownDescription: "The assets of an environment, optionally filtered",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"environment_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"organization_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"asset_platform": filter("Only return assets of this platform, e.g. aws"),
+			"asset_type":     filter("Only return assets of this type, e.g. rds"),
+			"name":           filter("Only return assets with this name parameter"),
+			"status":         filter("Only return assets in this status, e.g. DEPLOYED"),
+			"assets": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"id":                computed(types.StringType, ""),
+					"asset_platform":    computed(types.StringType, ""),
+					"asset_type":        computed(types.StringType, ""),
+					"asset_version":     computed(types.StringType, ""),
+					"name":              computed(types.StringType, "The name parameter of the asset, when it has one"),
+					"status":            computed(types.StringType, ""),
+					"parameters":        computed(types.StringType, "Current parameters of the asset as a json object"),
+					"outputs":           computed(types.MapType{ElemType: types.StringType}, "Outputs of the asset, values that aren't strings are json encoded"),
+					"sensitive_outputs": sensitiveOutputs,
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *AssetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assets"
+}
+
+func (r *AssetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *AssetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config AssetsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, err := r.client.ListAssets(ctx, config.OrganizationId.Value, config.EnvironmentId.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving assets",
+			err.Error(),
+		)
+		return
+	}
+
+	state := config
+	state.ID = config.EnvironmentId
+	state.Assets = []AssetModel{}
+	for _, asset := range assets {
+		model, err := assetOutputToModel(asset)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error retrieving assets",
+				fmt.Sprintf("Could not read asset %s: %s", asset.Id, err.Error()),
+			)
+			return
+		}
+		if matches(config, model) {
+			state.Assets = append(state.Assets, model)
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// matches - whether the asset passes every filter that is set
+func matches(config AssetsDataSourceModel, asset AssetModel) bool {
+	for _, f := range []struct{ filter, value types.String }{
+		{config.AssetPlatform, asset.AssetPlatform},
+		{config.AssetType, asset.AssetType},
+		{config.Name, asset.Name},
+		{config.Status, asset.Status},
+	} {
+		if !f.filter.Null && !f.filter.Unknown && f.filter.Value != f.value.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func assetOutputToModel(output cac.AssetOutput) (AssetModel, error) {
+	resource, err := assetOutputToPlan(context.Background(), ResourceModel{}, &output)
+	if err != nil {
+		return AssetModel{}, err
+	}
+
+	parameters := types.String{Null: true}
+	if output.CurrentAssetParameters.Data != nil {
+		bts, err := json.Marshal(output.CurrentAssetParameters.Data)
+		if err != nil {
+			return AssetModel{}, err
+		}
+		parameters = types.String{Value: string(bts)}
+	}
+
+	name := types.String{Null: true}
+	if value, ok := output.CurrentAssetParameters.Data["name"].(string); ok {
+		name = types.String{Value: value}
+	}
+
+	return AssetModel{
+		Id:               resource.Id,
+		AssetPlatform:    resource.AssetPlatform,
+		AssetType:        resource.AssetType,
+		AssetVersion:     resource.AssetVersion,
+		Name:             name,
+		Status:           resource.Status,
+		Parameters:       parameters,
+		Outputs:          resource.Outputs,
+		SensitiveOutputs: resource.SensitiveOutputs,
+	}, nil
+}
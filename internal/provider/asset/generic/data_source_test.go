@@ -0,0 +1,75 @@
+package generic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+func TestDataSourceFilters(t *testing.T) {
+	c, env := assettest.Setup(t)
+	ctx := context.Background()
+	orgId := env.Organization.Id
+
+	create := func(assetType, name string) cac.AssetOutput {
+		asset, err := c.CreateAsset(ctx, orgId, env.Id, cac.AssetInput{
+			Asset:           client.CompileAsset("aws", assetType, "latest"),
+			AssetVersion:    "latest",
+			AssetParameters: map[string]interface{}{"name": name},
+		})
+		assert.NoError(t, err)
+		deployed, err := util.WaitForAssetOperation(c, ctx, orgId, env.Id, asset.Id, asset.OperationId, time.Time{}, 0)
+		assert.NoError(t, err)
+		return *deployed
+	}
+	network := create("vpc", "network")
+	primary := create("rds", "primary")
+	create("rds", "replica")
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := func(config AssetsDataSourceModel) AssetsDataSourceModel {
+		t.Helper()
+		config.ID = types.String{Null: true}
+		config.OrganizationId = types.String{Value: orgId}
+		config.EnvironmentId = types.String{Value: env.Id}
+		for _, filter := range []*types.String{&config.AssetPlatform, &config.AssetType, &config.Name, &config.Status} {
+			if filter.Value == "" {
+				filter.Null = true
+			}
+		}
+
+		resp := assettest.ReadDataSource(t, d, config)
+		assettest.RequireNoError(t, resp.Diagnostics)
+		var state AssetsDataSourceModel
+		assettest.Get(t, resp.State, &state)
+		return state
+	}
+
+	assert.Len(t, read(AssetsDataSourceModel{}).Assets, 3)
+	assert.Len(t, read(AssetsDataSourceModel{AssetType: types.String{Value: "rds"}}).Assets, 2)
+	assert.Len(t, read(AssetsDataSourceModel{AssetPlatform: types.String{Value: "null"}}).Assets, 0)
+	assert.Len(t, read(AssetsDataSourceModel{Status: types.String{Value: "DEPLOYED"}}).Assets, 3)
+
+	state := read(AssetsDataSourceModel{AssetType: types.String{Value: "rds"}, Name: types.String{Value: "primary"}})
+	assert.Len(t, state.Assets, 1)
+	asset := state.Assets[0]
+	assert.Equal(t, primary.Id, asset.Id.Value)
+	assert.Equal(t, "aws", asset.AssetPlatform.Value)
+	assert.Equal(t, "latest", asset.AssetVersion.Value)
+	assert.Equal(t, `{"name":"primary"}`, asset.Parameters.Value)
+	assert.Contains(t, asset.Outputs.Elems, "uri_secret_arn")
+
+	state = read(AssetsDataSourceModel{Name: types.String{Value: "network"}})
+	assert.Len(t, state.Assets, 1)
+	assert.Equal(t, network.Id, state.Assets[0].Id.Value)
+}
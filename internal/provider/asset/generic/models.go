@@ -0,0 +1,233 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+)
+
+var resourceTypeName = "_asset"
+var resourceDescription = "Any asset bundle allowed in the environment, configured with a json object of " +
+	"parameters. Prefer the typed resources, e.g. `aptible_aws_rds`, when one exists for the bundle."
+
+var immutableAttributes = []string{"organization_id", "environment_id", "asset_platform", "asset_type"}
+
+// resourceTimeouts - generous since the bundle can be anything
+var resourceTimeouts = assetutil.TimeoutDefaults{
+	Create: 60 * time.Minute,
+	Update: 60 * time.Minute,
+	Delete: 30 * time.Minute,
+}
+
+// NewResource - unlike the typed asset resources, the bundle is named by the asset_platform and asset_type
+// attributes. An update replaces the parameters of the asset outright with the configured ones, nothing is merged
+// with what the asset has, so a key removed from them is removed from the asset
+func NewResource() resource.Resource {
+	return asset.NewResource(asset.ResourceConfig[ResourceModel]{
+		TypeName:            resourceTypeName,
+		Description:         resourceDescription,
+		Schema:              AssetSchema,
+		ImmutableAttributes: immutableAttributes,
+		Timeouts:            resourceTimeouts,
+		ToAssetInput:        planToAssetInput,
+		ToState:             assetOutputToPlan,
+	})
+}
+
+type ResourceModel struct {
+	Id             types.String        `tfsdk:"id" json:"id"`
+	AssetPlatform  types.String        `tfsdk:"asset_platform" json:"asset_platform"`
+	AssetType      types.String        `tfsdk:"asset_type" json:"asset_type"`
+	AssetVersion   types.String        `tfsdk:"asset_version" json:"asset_version"`
+	EnvironmentId  types.String        `tfsdk:"environment_id" json:"environment_id"`
+	OrganizationId types.String        `tfsdk:"organization_id" json:"organization_id"`
+	Status         types.String        `tfsdk:"status" json:"status"`
+	Timeouts       *assetutil.Timeouts `tfsdk:"timeouts"`
+
+	Parameters       types.String `tfsdk:"parameters" json:"parameters"`
+	Outputs          types.Map    `tfsdk:"outputs" json:"outputs"`
+	SensitiveOutputs types.Map    `tfsdk:"sensitive_outputs" json:"sensitive_outputs"`
+}
+
+var AssetSchema = map[string]tfsdk.Attribute{
+	"id": {
+		Description: "A valid asset id",
+		Type:        types.StringType,
+		Computed:    true,
+	},
+	"status": {
+		Type:     types.StringType,
+		Computed: true,
+	},
+
+	"environment_id": {
+		Description: "A valid environment id, defaults to the environment_id of the provider",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"organization_id": {
+		Description: "A valid organization id, defaults to the organization_id of the provider",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"asset_platform": {
+		Description: "Platform of the asset bundle, e.g. aws",
+		Type:        types.StringType,
+		Required:    true,
+		Validators:  []tfsdk.AttributeValidator{assetutil.NotEmpty()},
+	},
+	"asset_type": {
+		Description: "Type of the asset bundle, e.g. vpc",
+		Type:        types.StringType,
+		Required:    true,
+		Validators:  []tfsdk.AttributeValidator{assetutil.NotEmpty()},
+	},
+	"asset_version": {
+		Description: "Version of the asset bundle, the latest one the environment allows when not set. Changing it upgrades the asset in place",
+		Type:        types.StringType,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			resource.UseStateForUnknown(),
+		},
+	},
+	"parameters": {
+		Description: "Parameters of the asset as a json object, e.g. jsonencode({ name = \"network\" }). " +
+			"An update replaces the parameters of the asset with them, a key removed here is removed from the asset",
+		Type:       types.StringType,
+		Optional:   true,
+		Validators: []tfsdk.AttributeValidator{assetutil.JSONObject()},
+	},
+	"outputs": {
+		Description: "Outputs of the asset, values that aren't strings are json encoded",
+		Type:        types.MapType{ElemType: types.StringType},
+		Computed:    true,
+	},
+	"sensitive_outputs": {
+		Description: "Outputs of the asset flagged sensitive by the bundle, values that aren't strings are json encoded",
+		Type:        types.MapType{ElemType: types.StringType},
+		Computed:    true,
+		Sensitive:   true,
+	},
+}
+
+func planToAssetInput(ctx context.Context, plan ResourceModel) (cac.AssetInput, error) {
+	input, err := client.PopulateClientAssetInputForCreate(
+		ctx,
+		[]byte(plan.Parameters.Value),
+		plan.AssetType.Value,
+		plan.AssetPlatform.Value,
+		assetutil.AssetVersion(plan.AssetVersion),
+	)
+	if err != nil {
+		return cac.AssetInput{}, err
+	}
+	return *input, nil
+}
+
+func assetOutputToPlan(ctx context.Context, plan ResourceModel, output *cac.AssetOutput) (*ResourceModel, error) {
+	platform, assetType, _ := client.SplitAsset(output.Asset)
+	parameters, err := parametersToState(plan.Parameters, output.CurrentAssetParameters.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &ResourceModel{
+		Id:               types.String{Value: output.Id},
+		AssetPlatform:    types.String{Value: platform},
+		AssetType:        types.String{Value: assetType},
+		AssetVersion:     types.String{Value: output.AssetVersion},
+		EnvironmentId:    types.String{Value: output.Environment.Id},
+		OrganizationId:   types.String{Value: output.Environment.Organization.Id},
+		Status:           types.String{Value: string(output.Status)},
+		Timeouts:         plan.Timeouts,
+		Parameters:       parameters,
+		Outputs:          types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+		SensitiveOutputs: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+	}
+
+	if output.Outputs != nil {
+		for name, out := range *output.Outputs {
+			value, err := outputValue(out.Data)
+			if err != nil {
+				return nil, fmt.Errorf("output %s: %w", name, err)
+			}
+			if out.Sensitive {
+				model.SensitiveOutputs.Elems[name] = value
+			} else {
+				model.Outputs.Elems[name] = value
+			}
+		}
+	}
+
+	return model, nil
+}
+
+// parametersToState - the configured parameters are kept as written unless the asset disagrees with them,
+// then only the configured keys are read back so parameters the backend defaults don't show as a diff.
+// When nothing was configured (e.g. on import) every parameter of the asset is read back.
+func parametersToState(configured types.String, current map[string]interface{}) (types.String, error) {
+	unset := configured.Null || configured.Value == ""
+	if configured.Unknown || (unset && len(current) == 0) {
+		return types.String{Null: true}, nil
+	}
+
+	remote := map[string]interface{}{}
+	if unset {
+		remote = current
+	} else {
+		planned := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(configured.Value), &planned); err != nil {
+			return types.String{}, fmt.Errorf("parameters must be a json object: %w", err)
+		}
+		for key := range planned {
+			if value, ok := current[key]; ok {
+				remote[key] = value
+			}
+		}
+		if reflect.DeepEqual(planned, remote) {
+			return configured, nil
+		}
+	}
+
+	bts, err := json.Marshal(remote)
+	if err != nil {
+		return types.String{}, err
+	}
+	return types.String{Value: string(bts)}, nil
+}
+
+func outputValue(data interface{}) (types.String, error) {
+	switch value := data.(type) {
+	case nil:
+		return types.String{Null: true}, nil
+	case string:
+		return types.String{Value: value}, nil
+	default:
+		bts, err := json.Marshal(value)
+		if err != nil {
+			return types.String{}, err
+		}
+		return types.String{Value: string(bts)}, nil
+	}
+}
@@ -0,0 +1,168 @@
+package generic
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	c.Outputs = func(asset cac.AssetOutput) map[string]cac.AssetTerraformOutput {
+		return map[string]cac.AssetTerraformOutput{
+			"arn":      {Data: "arn:aws:null:" + asset.Id},
+			"subnets":  {Data: []interface{}{"a", "b"}},
+			"password": {Data: "hunter2", Sensitive: true},
+		}
+	}
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		Id:               types.String{Unknown: true},
+		AssetPlatform:    types.String{Value: "null"},
+		AssetType:        types.String{Value: "simple"},
+		AssetVersion:     types.String{Unknown: true},
+		Status:           types.String{Unknown: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		Parameters:       types.String{Value: `{"name":"my_null","size":2}`},
+		Outputs:          types.Map{ElemType: types.StringType, Unknown: true},
+		SensitiveOutputs: types.Map{ElemType: types.StringType, Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.Id.Value)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "latest", state.AssetVersion.Value)
+	assert.Equal(t, `{"name":"my_null","size":2}`, state.Parameters.Value)
+	assert.Equal(t, types.String{Value: `["a","b"]`}, state.Outputs.Elems["subnets"])
+	assert.Equal(t, types.String{Value: "hunter2"}, state.SensitiveOutputs.Elems["password"])
+	assert.NotContains(t, state.Outputs.Elems, "password")
+
+	asset, err := c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, "null__simple__latest", asset.Asset)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.Parameters = types.String{Value: `{"name":"my_null","size":3}`}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, `{"name":"my_null","size":3}`, state.Parameters.Value)
+
+	// a key removed from the configuration is removed from the asset
+	planned = state
+	planned.Parameters = types.String{Value: `{"name":"my_null"}`}
+	updated = assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, `{"name":"my_null"}`, state.Parameters.Value)
+	asset, err = c.DescribeAsset(context.Background(), env.Organization.Id, env.Id, state.Id.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "my_null"}, asset.CurrentAssetParameters.Data)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+}
+
+func TestResourceCreateRejectsInvalidParameters(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	created := assettest.Create(t, r, ResourceModel{
+		Id:               types.String{Unknown: true},
+		AssetPlatform:    types.String{Value: "null"},
+		AssetType:        types.String{Value: "simple"},
+		AssetVersion:     types.String{Unknown: true},
+		Status:           types.String{Unknown: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		Parameters:       types.String{Value: `not json`},
+		Outputs:          types.Map{ElemType: types.StringType, Unknown: true},
+		SensitiveOutputs: types.Map{ElemType: types.StringType, Unknown: true},
+	})
+	assert.True(t, created.Diagnostics.HasError())
+}
+
+func TestResourceAssetVersion(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	config := ResourceModel{
+		Id:               types.String{Null: true},
+		AssetPlatform:    types.String{Value: "aws"},
+		AssetType:        types.String{Value: "vpc"},
+		AssetVersion:     types.String{Value: "latest"},
+		Status:           types.String{Null: true},
+		EnvironmentId:    types.String{Value: env.Id},
+		OrganizationId:   types.String{Value: env.Organization.Id},
+		Parameters:       types.String{Value: `{"name":"network"}`},
+		Outputs:          types.Map{ElemType: types.StringType, Null: true},
+		SensitiveOutputs: types.Map{ElemType: types.StringType, Null: true},
+	}
+	plan := config
+	plan.Id = types.String{Unknown: true}
+	plan.Status = types.String{Unknown: true}
+	plan.Outputs = types.Map{ElemType: types.StringType, Unknown: true}
+	plan.SensitiveOutputs = types.Map{ElemType: types.StringType, Unknown: true}
+
+	resp := assettest.ModifyPlan(t, r, config, plan)
+	assettest.RequireNoError(t, resp.Diagnostics)
+
+	// the bundle is looked up by the planned platform and type
+	config.AssetType = types.String{Value: "simple"}
+	plan.AssetType = config.AssetType
+	resp = assettest.ModifyPlan(t, r, config, plan)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "does not allow aws simple assets")
+
+	// without a pinned version the latest one is used and nothing is checked
+	config.AssetVersion = types.String{Null: true}
+	plan.AssetVersion = types.String{Unknown: true}
+	resp = assettest.ModifyPlan(t, r, config, plan)
+	assettest.RequireNoError(t, resp.Diagnostics)
+}
+
+func TestParametersToState(t *testing.T) {
+	current := map[string]interface{}{"name": "db", "port": float64(5432), "backup": "01:00"}
+
+	// backend defaults that weren't configured don't show as a diff
+	configured := types.String{Value: `{"port": 5432, "name": "db"}`}
+	state, err := parametersToState(configured, current)
+	assert.NoError(t, err)
+	assert.Equal(t, configured, state)
+
+	// drift in a configured parameter does
+	state, err = parametersToState(types.String{Value: `{"name":"other"}`}, current)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"db"}`, state.Value)
+
+	// nothing configured, e.g. on import
+	state, err = parametersToState(types.String{Null: true}, current)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"backup":"01:00","name":"db","port":5432}`, state.Value)
+}
@@ -0,0 +1,477 @@
+/*
+Package asset implements the terraform resource every asset type shares. An
+asset type only describes itself, its schema, model and the converters between
+the model and the cloud api, with a ResourceConfig.
+*/
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// ResourceConfig - what an asset type needs to be a resource. M is the model of the asset, its schema must
+// have the attributes every asset shares: id, status, organization_id, environment_id, asset_version and
+// the timeouts block.
+type ResourceConfig[M any] struct {
+	// TypeName - suffix of the resource type name, e.g. _aws_rds
+	TypeName string
+	// Description - markdown description of the resource
+	Description string
+	// Platform - platform of the bundle, e.g. aws. When empty the asset_platform and asset_type attributes
+	// of the plan name the bundle
+	Platform string
+	// AssetType - type of the bundle, e.g. rds for aws__rds__latest
+	AssetType string
+	// Schema - attributes of the asset
+	Schema map[string]tfsdk.Attribute
+	// ImmutableAttributes - what the backend can't change on an existing asset, changing any of them replaces it.
+	// organization_id and environment_id belong in every list, an asset can't move
+	ImmutableAttributes []string
+	// Timeouts - how long to wait for the asset to settle after each operation when the timeouts block
+	// leaves it unset, also shown as the defaults in the description of the block
+	Timeouts assetutil.TimeoutDefaults
+
+	// ToAssetInput - the asset the plan describes, as sent to the cloud api
+	ToAssetInput func(ctx context.Context, plan M) (cac.AssetInput, error)
+	// ToState - the model of an asset, plan is the prior plan or state, empty on import
+	ToState func(ctx context.Context, plan M, output *cac.AssetOutput) (*M, error)
+}
+
+// NewResource - the resource of an asset type
+func NewResource[M any](config ResourceConfig[M]) resource.Resource {
+	return &Resource[M]{config: config}
+}
+
+var _ resource.ResourceWithImportState = &Resource[struct{}]{}
+var _ resource.ResourceWithModifyPlan = &Resource[struct{}]{}
+
+type Resource[M any] struct {
+	client   client.CloudClient
+	provider *util.ProviderData
+	config   ResourceConfig[M]
+}
+
+// assetAttributes - the attributes every asset model shares, read by name since the model is opaque here
+type assetAttributes struct {
+	Id             types.String
+	OrganizationId types.String
+	EnvironmentId  types.String
+	Timeouts       *assetutil.Timeouts
+}
+
+// attributeGetter - a plan or a state
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target interface{}) diag.Diagnostics
+}
+
+func getAssetAttributes(ctx context.Context, data attributeGetter) (assetAttributes, diag.Diagnostics) {
+	var attributes assetAttributes
+	var diags diag.Diagnostics
+	diags.Append(data.GetAttribute(ctx, path.Root("id"), &attributes.Id)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("organization_id"), &attributes.OrganizationId)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("environment_id"), &attributes.EnvironmentId)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("timeouts"), &attributes.Timeouts)...)
+	return attributes, diags
+}
+
+func (r Resource[M]) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes, diags := assetutil.RequiresReplace(r.config.Schema, r.config.ImmutableAttributes)
+	return tfsdk.Schema{
+		MarkdownDescription: r.config.Description,
+		Attributes:          attributes,
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": assetutil.TimeoutsBlock(r.config.Timeouts),
+		},
+	}, diags
+}
+
+func (r *Resource[M]) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.config.TypeName
+}
+
+func (r *Resource[M]) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.provider = data
+}
+
+// ModifyPlan - fall back to the provider's organization_id and environment_id when the resource omits them,
+// and check a pinned asset_version is one the environment allows
+func (r *Resource[M]) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.SetPlanDefaults(ctx, r.provider, map[string]string{
+		"organization_id": "organization_id",
+		"environment_id":  "environment_id",
+	}, req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	platform, assetType := types.String{Value: r.config.Platform}, types.String{Value: r.config.AssetType}
+	if r.config.Platform == "" {
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("asset_platform"), &platform)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("asset_type"), &assetType)...)
+		if resp.Diagnostics.HasError() || platform.Unknown || assetType.Unknown {
+			return
+		}
+	}
+	assetutil.ValidatePlanAssetVersion(ctx, r.client, platform.Value, assetType.Value, req, resp)
+}
+
+func (r *Resource[M]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan M
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	attributes, diags := getAssetAttributes(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the plan is not logged whole, it can hold sensitive values like secret_string
+	tflog.Info(ctx, "Creating asset", map[string]interface{}{
+		"organization_id": attributes.OrganizationId.Value,
+		"environment_id":  attributes.EnvironmentId.Value,
+	})
+
+	assetInput, err := r.config.ToAssetInput(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating asset",
+			"Could not build asset parameters: "+err.Error(),
+		)
+		return
+	}
+
+	requestedAt := time.Now()
+	createdAsset, err := r.client.CreateAsset(
+		ctx,
+		attributes.OrganizationId.Value,
+		attributes.EnvironmentId.Value,
+		assetInput,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating asset",
+			"Could not create asset, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Info(
+		ctx, "created asset",
+		map[string]interface{}{
+			"id":     createdAsset.Id,
+			"status": createdAsset.Status,
+		},
+	)
+
+	nextPlan, err := r.config.ToState(ctx, plan, createdAsset)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating asset",
+			fmt.Sprintf(
+				"Error when creating asset %s: %s",
+				createdAsset.Id,
+				err.Error(),
+			),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, nextPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	completedAsset, err := util.WaitForAssetOperation(
+		r.client,
+		ctx,
+		attributes.OrganizationId.Value,
+		attributes.EnvironmentId.Value,
+		createdAsset.Id,
+		createdAsset.OperationId,
+		requestedAt,
+		r.config.Timeouts.CreateTimeout(attributes.Timeouts),
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for asset on create",
+			fmt.Sprintf(
+				"Error when waiting for asset id %s: %s",
+				createdAsset.Id,
+				err.Error(),
+			),
+		)
+		return
+	}
+
+	nextPlan, err = r.config.ToState(ctx, plan, completedAsset)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating asset",
+			fmt.Sprintf(
+				"Error when creating asset %s: %s",
+				createdAsset.Id,
+				err.Error(),
+			),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, nextPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource[M]) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state M
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	attributes, diags := getAssetAttributes(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetClientOutput, err := r.client.DescribeAsset(ctx, attributes.OrganizationId.Value, attributes.EnvironmentId.Value, attributes.Id.Value)
+	if assetutil.IsRemoved(assetClientOutput, err) {
+		tflog.Warn(ctx, "Asset no longer exists, removing it from state", map[string]interface{}{"id": attributes.Id.Value})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading asset",
+			fmt.Sprintf(
+				"Error when reading asset %s: %s",
+				attributes.Id.Value,
+				err.Error(),
+			),
+		)
+		return
+	}
+
+	asset, err := r.config.ToState(ctx, state, assetClientOutput)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error get asset when trying to update (refreshing state)",
+			"Could get asset when trying to update (refreshing state): "+attributes.Id.Value+": "+err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, asset)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource[M]) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Get plan values
+	var plan M
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	attributes, diags := getAssetAttributes(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the id from state, it is unknown in a plan only when the asset is being replaced
+	var assetId types.String
+	diags = req.State.GetAttribute(ctx, path.Root("id"), &assetId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get current state and compare against remote
+	assetInCloudApi, err := r.client.DescribeAsset(
+		ctx,
+		attributes.OrganizationId.Value,
+		attributes.EnvironmentId.Value,
+		assetId.Value,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error update asset",
+			"Could not update asset id "+assetId.Value+": "+err.Error(),
+		)
+		return
+	}
+
+	assetInput, err := r.config.ToAssetInput(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error update asset",
+			"Could not build asset parameters: "+err.Error(),
+		)
+		return
+	}
+
+	// request update
+	requestedAt := time.Now()
+	result, err := r.client.UpdateAsset(
+		ctx,
+		assetInCloudApi.Id,
+		assetInCloudApi.Environment.Id,
+		assetInCloudApi.Environment.Organization.Id,
+		assetInput,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error requesting update from cloud api",
+			fmt.Sprintf("Could not update asset %s: %s", assetInCloudApi.Id, err.Error()),
+		)
+		return
+	}
+
+	completedAsset, err := util.WaitForAssetOperation(
+		r.client,
+		ctx,
+		result.Environment.Organization.Id,
+		result.Environment.Id,
+		result.Id,
+		result.OperationId,
+		requestedAt,
+		r.config.Timeouts.UpdateTimeout(attributes.Timeouts),
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for asset on update",
+			fmt.Sprintf("Error when waiting for asset id: %s: %s", result.Id, err.Error()),
+		)
+		return
+	}
+
+	stateToSet, err := r.config.ToState(ctx, plan, completedAsset)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error get asset when trying to update (refreshing state)",
+			fmt.Sprintf("Could get asset when trying to update (refreshing state): %s: %s", assetInCloudApi.Id, err.Error()),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, stateToSet)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource[M]) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state, diags := getAssetAttributes(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete asset by calling API
+	requestedAt := time.Now()
+	destroyedAsset, err := r.client.DestroyAsset(ctx, state.OrganizationId.Value, state.EnvironmentId.Value, state.Id.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting asset",
+			fmt.Sprintf("Could not delete asset id %s: %s", state.Id.Value, err.Error()),
+		)
+		return
+	}
+
+	var operationId *string
+	if destroyedAsset != nil {
+		operationId = destroyedAsset.OperationId
+	}
+	_, err = util.WaitForAssetOperation(
+		r.client,
+		ctx,
+		state.OrganizationId.Value,
+		state.EnvironmentId.Value,
+		state.Id.Value,
+		operationId,
+		requestedAt,
+		r.config.Timeouts.DeleteTimeout(state.Timeouts),
+	)
+
+	// the asset may be gone entirely once its destroy operation completes
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error waiting for asset on delete",
+			fmt.Sprintf("Error when waiting for asset id %s: %s", state.Id.Value, err.Error()),
+		)
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *Resource[M]) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	assetClientOutput := assetutil.StateImporter(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var empty M
+	asset, err := r.config.ToState(ctx, empty, assetClientOutput)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error get asset when trying to update during import (refreshing state)",
+			"Could get asset when trying to update during import (refreshing state): "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, asset)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,117 @@
+package asset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+)
+
+// testModel - the least an asset type has, the shared attributes and one parameter
+type testModel struct {
+	Id             types.String        `tfsdk:"id"`
+	AssetVersion   types.String        `tfsdk:"asset_version"`
+	EnvironmentId  types.String        `tfsdk:"environment_id"`
+	OrganizationId types.String        `tfsdk:"organization_id"`
+	Status         types.String        `tfsdk:"status"`
+	Timeouts       *assetutil.Timeouts `tfsdk:"timeouts"`
+
+	Name types.String `tfsdk:"name"`
+}
+
+func newTestResource() resource.Resource {
+	return NewResource(ResourceConfig[testModel]{
+		TypeName:  "_aws_vpc",
+		Platform:  "aws",
+		AssetType: "vpc",
+		Schema: map[string]tfsdk.Attribute{
+			"id":              {Type: types.StringType, Computed: true},
+			"status":          {Type: types.StringType, Computed: true},
+			"asset_version":   {Type: types.StringType, Optional: true, Computed: true},
+			"environment_id":  {Type: types.StringType, Optional: true, Computed: true},
+			"organization_id": {Type: types.StringType, Optional: true, Computed: true},
+			"name":            {Type: types.StringType, Required: true},
+		},
+		ImmutableAttributes: []string{"name"},
+		Timeouts:            assetutil.TimeoutDefaults{Create: time.Minute, Update: time.Minute, Delete: time.Minute},
+		ToAssetInput: func(ctx context.Context, plan testModel) (cac.AssetInput, error) {
+			return cac.AssetInput{
+				Asset:           client.CompileAsset("aws", "vpc", assetutil.AssetVersion(plan.AssetVersion)),
+				AssetVersion:    assetutil.AssetVersion(plan.AssetVersion),
+				AssetParameters: map[string]interface{}{"name": plan.Name.Value},
+			}, nil
+		},
+		ToState: func(ctx context.Context, plan testModel, output *cac.AssetOutput) (*testModel, error) {
+			return &testModel{
+				Id:             types.String{Value: output.Id},
+				AssetVersion:   types.String{Value: output.AssetVersion},
+				EnvironmentId:  types.String{Value: output.Environment.Id},
+				OrganizationId: types.String{Value: output.Environment.Organization.Id},
+				Status:         types.String{Value: string(output.Status)},
+				Timeouts:       plan.Timeouts,
+				Name:           types.String{Value: output.CurrentAssetParameters.Data["name"].(string)},
+			}, nil
+		},
+	})
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := newTestResource()
+	assettest.Configure(t, r, c)
+
+	schema := assettest.Schema(t, r)
+	assert.Contains(t, schema.Attributes["name"].PlanModifiers, resource.RequiresReplace())
+	assert.Contains(t, schema.Blocks, "timeouts")
+
+	created := assettest.Create(t, r, testModel{
+		Id:             types.String{Unknown: true},
+		AssetVersion:   types.String{Unknown: true},
+		Status:         types.String{Unknown: true},
+		EnvironmentId:  types.String{Value: env.Id},
+		OrganizationId: types.String{Value: env.Organization.Id},
+		Name:           types.String{Value: "network"},
+	})
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state testModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, string(cac.ASSETSTATUS_DEPLOYED), state.Status.Value)
+	assert.Equal(t, "latest", state.AssetVersion.Value)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed testModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.Name = types.String{Value: "renamed"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "renamed", state.Name.Value)
+
+	imported := assettest.ImportState(t, r, assettest.ImportId(env, state.Id.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState testModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	// an asset destroyed out of band is dropped from state
+	read = assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	assert.True(t, assettest.IsRemoved(read.State))
+}
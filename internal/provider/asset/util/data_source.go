@@ -0,0 +1,244 @@
+package assetutil
+
+import (
+	"context"
+	"fmt"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// DataSourceConfig - what a typed asset data source needs to know about its asset type
+type DataSourceConfig struct {
+	// TypeName - suffix of the data source type name, the same as the resource's, e.g. _aws_rds
+	TypeName string
+	// AssetType - type of the bundle, e.g. rds for aws__rds__latest
+	AssetType string
+	// LookupKey - parameter the asset is looked up by, name when empty
+	LookupKey string
+	// Description - markdown description of the data source
+	Description string
+	// NewResource - the resource of the asset type, its schema and state drive the data source's
+	NewResource func() resource.Resource
+	// ToState - the resource model of an asset, as set on import
+	ToState func(ctx context.Context, output *cac.AssetOutput) (interface{}, error)
+}
+
+// NewDataSource - a data source finding an asset of the configured type by its lookup key in an
+// environment, exposing every attribute of the resource as computed
+func NewDataSource(config DataSourceConfig) datasource.DataSource {
+	if config.LookupKey == "" {
+		config.LookupKey = "name"
+	}
+	return &DataSource{config: config}
+}
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSourceWithConfigure = &DataSource{}
+
+type DataSource struct {
+	client client.CloudClient
+	config DataSourceConfig
+}
+
+func (d *DataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	resourceSchema, diags := d.config.NewResource().GetSchema(ctx)
+	if diags.HasError() {
+		return tfsdk.Schema{}, diags
+	}
+
+	attributes := DataSourceAttributes(resourceSchema.Attributes)
+	for _, key := range []string{"organization_id", "environment_id", d.config.LookupKey} {
+		attribute := attributes[key]
+		attribute.Computed = false
+		attribute.Required = true
+		attributes[key] = attribute
+	}
+	// unlike the resource, the data source does not fall back to the provider's ids
+	for key, description := range map[string]string{
+		"organization_id": "A valid organization id",
+		"environment_id":  "A valid environment id",
+	} {
+		attribute := attributes[key]
+		attribute.Description = description
+		attributes[key] = attribute
+	}
+
+	return tfsdk.Schema{
+		MarkdownDescription: d.config.Description,
+		Attributes:          attributes,
+	}, diags
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + d.config.TypeName
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var orgId, envId, lookup types.String
+	for key, target := range map[string]*types.String{
+		"organization_id":  &orgId,
+		"environment_id":   &envId,
+		d.config.LookupKey: &lookup,
+	} {
+		diags := req.Config.GetAttribute(ctx, path.Root(key), target)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := FindAsset(ctx, d.client, orgId.Value, envId.Value, d.config.AssetType, d.config.LookupKey, lookup.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error retrieving %s", d.config.AssetType),
+			err.Error(),
+		)
+		return
+	}
+
+	model, err := d.config.ToState(ctx, asset)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error retrieving %s", d.config.AssetType),
+			fmt.Sprintf("Could not read asset %s: %s", asset.Id, err.Error()),
+		)
+		return
+	}
+
+	resourceSchema, diags := d.config.NewResource().GetSchema(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setDataSourceState(ctx, resourceSchema, model, &resp.State)...)
+}
+
+// FindAsset - the one asset of assetType in the environment whose parameter key is value
+func FindAsset(ctx context.Context, c client.CloudClient, orgId, envId, assetType, key, value string) (*cac.AssetOutput, error) {
+	assets, err := c.ListAssets(ctx, orgId, envId)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []cac.AssetOutput{}
+	for _, asset := range assets {
+		if _, t, _ := client.SplitAsset(asset.Asset); t != assetType || asset.Status == cac.ASSETSTATUS_DESTROYED {
+			continue
+		}
+		if v, ok := asset.CurrentAssetParameters.Data[key].(string); ok && v == value {
+			matches = append(matches, asset)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s with %s %q found in environment %s", assetType, key, value, envId)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := []string{}
+		for _, asset := range matches {
+			ids = append(ids, asset.Id)
+		}
+		return nil, fmt.Errorf("%d assets of type %s with %s %q found in environment %s, expected one: %v", len(matches), assetType, key, value, envId, ids)
+	}
+}
+
+// DataSourceAttributes - copy resource attributes as computed-only data source attributes, keeping their
+// types, descriptions and sensitivity
+func DataSourceAttributes(attributes map[string]tfsdk.Attribute) map[string]tfsdk.Attribute {
+	out := map[string]tfsdk.Attribute{}
+	for name, attribute := range attributes {
+		computed := tfsdk.Attribute{
+			Type:                attribute.Type,
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			Computed:            true,
+		}
+		if attribute.Attributes != nil {
+			setDataSourceNestedAttributes(attribute, &computed)
+		}
+		out[name] = computed
+	}
+	return out
+}
+
+// setDataSourceNestedAttributes - set the nested attributes of src on dst as computed-only, with the same
+// nesting mode
+func setDataSourceNestedAttributes(src tfsdk.Attribute, dst *tfsdk.Attribute) {
+	nested := src.Attributes
+	children := map[string]tfsdk.Attribute{}
+	for name, child := range nested.GetAttributes() {
+		if attribute, ok := child.(tfsdk.Attribute); ok {
+			children[name] = attribute
+		}
+	}
+	children = DataSourceAttributes(children)
+
+	switch nested.GetNestingMode() {
+	case tfsdk.ListNestedAttributes(nil).GetNestingMode():
+		dst.Attributes = tfsdk.ListNestedAttributes(children)
+	case tfsdk.SetNestedAttributes(nil).GetNestingMode():
+		dst.Attributes = tfsdk.SetNestedAttributes(children)
+	case tfsdk.MapNestedAttributes(nil).GetNestingMode():
+		dst.Attributes = tfsdk.MapNestedAttributes(children)
+	default:
+		dst.Attributes = tfsdk.SingleNestedAttributes(children)
+	}
+}
+
+// setDataSourceState - set the resource model as the data source state, dropping what only the resource
+// has, e.g. its timeouts block
+func setDataSourceState(ctx context.Context, resourceSchema tfsdk.Schema, model interface{}, state *tfsdk.State) diag.Diagnostics {
+	resourceState := tfsdk.State{Schema: resourceSchema}
+	diags := resourceState.Set(ctx, model)
+	if diags.HasError() {
+		return diags
+	}
+
+	values := map[string]tftypes.Value{}
+	if err := resourceState.Raw.As(&values); err != nil {
+		diags.AddError("Error converting asset state", err.Error())
+		return diags
+	}
+
+	stateType := state.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	for name := range values {
+		if _, ok := stateType.AttributeTypes[name]; !ok {
+			delete(values, name)
+		}
+	}
+	state.Raw = tftypes.NewValue(stateType, values)
+	return diags
+}
@@ -0,0 +1,53 @@
+package assetutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+func TestFindAsset(t *testing.T) {
+	c, env := assettest.Setup(t)
+	ctx := context.Background()
+	orgId := env.Organization.Id
+
+	create := func(assetType, name string) cac.AssetOutput {
+		asset, err := c.CreateAsset(ctx, orgId, env.Id, cac.AssetInput{
+			Asset:           client.CompileAsset("aws", assetType, "latest"),
+			AssetVersion:    "latest",
+			AssetParameters: map[string]interface{}{"name": name},
+		})
+		assert.NoError(t, err)
+		return *asset
+	}
+	db := create("rds", "db")
+	create("vpc", "db")
+	create("rds", "twin")
+	create("rds", "twin")
+	gone := create("rds", "gone")
+	destroying, err := c.DestroyAsset(ctx, orgId, env.Id, gone.Id)
+	assert.NoError(t, err)
+	_, err = util.WaitForAssetOperation(c, ctx, orgId, env.Id, gone.Id, destroying.OperationId, time.Time{}, 0)
+	assert.NoError(t, err)
+
+	found, err := FindAsset(ctx, c, orgId, env.Id, "rds", "name", "db")
+	assert.NoError(t, err)
+	assert.Equal(t, db.Id, found.Id)
+
+	_, err = FindAsset(ctx, c, orgId, env.Id, "rds", "name", "missing")
+	assert.ErrorContains(t, err, `no rds with name "missing" found`)
+
+	// destroyed assets are kept by the api but are never a match
+	_, err = FindAsset(ctx, c, orgId, env.Id, "rds", "name", "gone")
+	assert.ErrorContains(t, err, `no rds with name "gone" found`)
+
+	_, err = FindAsset(ctx, c, orgId, env.Id, "rds", "name", "twin")
+	assert.ErrorContains(t, err, `2 assets of type rds with name "twin"`)
+}
@@ -0,0 +1,36 @@
+package assetutil
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// RequiresReplace - a copy of the schema attributes where changing any of the named ones plans the asset
+// for replacement, for what the backend can't change on an existing asset. The attributes' own plan
+// modifiers run first, so a computed attribute kept from state isn't seen as a change.
+func RequiresReplace(attributes map[string]tfsdk.Attribute, names []string) (map[string]tfsdk.Attribute, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	out := make(map[string]tfsdk.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		out[name] = attribute
+	}
+
+	for _, name := range names {
+		attribute, ok := out[name]
+		if !ok {
+			diags.AddError(
+				"Invalid asset schema",
+				fmt.Sprintf("%s is listed as immutable but is not an attribute of the asset. Please report this issue to the provider developers.", name),
+			)
+			continue
+		}
+		modifiers := make(tfsdk.AttributePlanModifiers, 0, len(attribute.PlanModifiers)+1)
+		modifiers = append(modifiers, attribute.PlanModifiers...)
+		attribute.PlanModifiers = append(modifiers, resource.RequiresReplace())
+		out[name] = attribute
+	}
+	return out, diags
+}
@@ -0,0 +1,45 @@
+package assetutil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiresReplace(t *testing.T) {
+	attributes := map[string]tfsdk.Attribute{
+		"environment_id": {
+			Type:     types.StringType,
+			Optional: true,
+			Computed: true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"name": {
+			Type:     types.StringType,
+			Required: true,
+		},
+		"size": {
+			Type:     types.Int64Type,
+			Required: true,
+		},
+	}
+
+	replaced, diags := RequiresReplace(attributes, []string{"environment_id", "name"})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, tfsdk.AttributePlanModifiers{resource.UseStateForUnknown(), resource.RequiresReplace()}, replaced["environment_id"].PlanModifiers)
+	assert.Equal(t, tfsdk.AttributePlanModifiers{resource.RequiresReplace()}, replaced["name"].PlanModifiers)
+	assert.Empty(t, replaced["size"].PlanModifiers)
+
+	// the shared schema is left as it was
+	assert.Len(t, attributes["environment_id"].PlanModifiers, 1)
+	assert.Empty(t, attributes["name"].PlanModifiers)
+
+	_, diags = RequiresReplace(attributes, []string{"missing"})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), "missing is listed as immutable")
+}
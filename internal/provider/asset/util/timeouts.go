@@ -0,0 +1,120 @@
+package assetutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Timeouts - the `timeouts` block of an asset resource, nil when the block is not configured
+type Timeouts struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// TimeoutDefaults - how long each operation on an asset type may take when its timeout is not configured
+type TimeoutDefaults struct {
+	Create time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// TimeoutsBlock - the `timeouts { create = "90m" update = "30m" delete = "20m" }` block shared by every asset
+// resource, documented with the defaults of the asset type
+func TimeoutsBlock(defaults TimeoutDefaults) tfsdk.Block {
+	attribute := func(operation string, d time.Duration) tfsdk.Attribute {
+		return tfsdk.Attribute{
+			Description: fmt.Sprintf(
+				"How long to wait for the asset to %s, as a duration like \"30m\" or \"1h30m\" (default: %s)",
+				operation, d,
+			),
+			Type:       types.StringType,
+			Optional:   true,
+			Validators: []tfsdk.AttributeValidator{Duration()},
+		}
+	}
+
+	return tfsdk.Block{
+		Description: "Limits on how long the provider waits for the asset to settle after each operation",
+		NestingMode: tfsdk.BlockNestingModeSingle,
+		Attributes: map[string]tfsdk.Attribute{
+			"create": attribute("be created", defaults.Create),
+			"update": attribute("be updated", defaults.Update),
+			"delete": attribute("be destroyed", defaults.Delete),
+		},
+	}
+}
+
+// CreateTimeout - the configured create timeout, or the default of the asset type
+func (d TimeoutDefaults) CreateTimeout(t *Timeouts) time.Duration {
+	if t == nil {
+		return d.Create
+	}
+	return ParseTimeout(t.Create, d.Create)
+}
+
+// UpdateTimeout - the configured update timeout, or the default of the asset type
+func (d TimeoutDefaults) UpdateTimeout(t *Timeouts) time.Duration {
+	if t == nil {
+		return d.Update
+	}
+	return ParseTimeout(t.Update, d.Update)
+}
+
+// DeleteTimeout - the configured delete timeout, or the default of the asset type
+func (d TimeoutDefaults) DeleteTimeout(t *Timeouts) time.Duration {
+	if t == nil {
+		return d.Delete
+	}
+	return ParseTimeout(t.Delete, d.Delete)
+}
+
+// ParseTimeout - the value was already validated against the schema, fall back on the default when unset
+func ParseTimeout(value types.String, fallback time.Duration) time.Duration {
+	if value.Null || value.Unknown || value.Value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+// Duration - the value is a positive duration, e.g. 30m or 1h30m
+func Duration() tfsdk.AttributeValidator {
+	return durationValidator{}
+}
+
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, e.g. 30m or 1h30m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	d, err := time.ParseDuration(value.Value)
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("must be greater than zero")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid timeout",
+			fmt.Sprintf("%q is not a valid timeout, %s: %s", value.Value, v.Description(ctx), err),
+		)
+	}
+}
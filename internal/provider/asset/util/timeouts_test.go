@@ -0,0 +1,52 @@
+package assetutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDefaults = TimeoutDefaults{
+	Create: time.Hour,
+	Update: 30 * time.Minute,
+	Delete: 20 * time.Minute,
+}
+
+func TestTimeoutDefaults(t *testing.T) {
+	assert.Equal(t, time.Hour, testDefaults.CreateTimeout(nil))
+	assert.Equal(t, 30*time.Minute, testDefaults.UpdateTimeout(nil))
+	assert.Equal(t, 20*time.Minute, testDefaults.DeleteTimeout(nil))
+
+	configured := &Timeouts{
+		Create: types.String{Value: "90m"},
+		Update: types.String{Null: true},
+		Delete: types.String{Value: "1h"},
+	}
+	assert.Equal(t, 90*time.Minute, testDefaults.CreateTimeout(configured))
+	assert.Equal(t, 30*time.Minute, testDefaults.UpdateTimeout(configured))
+	assert.Equal(t, time.Hour, testDefaults.DeleteTimeout(configured))
+}
+
+func TestDurationValidator(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"30m":    true,
+		"1h30m":  true,
+		"45s":    true,
+		"0s":     false,
+		"-5m":    false,
+		"thirty": false,
+		"30":     false,
+	} {
+		resp := &tfsdk.ValidateAttributeResponse{}
+		durationValidator{}.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root("timeouts").AtName("create"),
+			AttributeConfig: types.String{Value: value},
+		}, resp)
+		assert.Equal(t, !valid, resp.Diagnostics.HasError(), value)
+	}
+}
@@ -57,3 +57,12 @@ func StateImporter(ctx context.Context, client client.CloudClient, req resource.
 
 	return assetClientOutput
 }
+
+// IsRemoved - the asset is gone from the cloud api, either deleted out of band (404) or destroyed,
+// and should be dropped from state so terraform proposes to recreate it
+func IsRemoved(asset *cac.AssetOutput, err error) bool {
+	if err != nil {
+		return client.IsNotFound(err)
+	}
+	return asset != nil && asset.Status == cac.ASSETSTATUS_DESTROYED
+}
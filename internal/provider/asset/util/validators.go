@@ -0,0 +1,194 @@
+package assetutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// hh24:mi-hh24:mi, e.g. 00:00-03:00
+	timeRangeRegexp = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d-([01]\d|2[0-3]):[0-5]\d$`)
+	// ddd:hh24:mi-ddd:hh24:mi, e.g. sun:05:00-sun:09:00
+	weeklyTimeRangeRegexp = regexp.MustCompile(
+		`^(?i:mon|tue|wed|thu|fri|sat|sun):([01]\d|2[0-3]):[0-5]\d-(?i:mon|tue|wed|thu|fri|sat|sun):([01]\d|2[0-3]):[0-5]\d$`,
+	)
+	// one label of a domain name, letters, digits and hyphens not starting or ending with a hyphen
+	domainLabelRegexp = regexp.MustCompile(`^(?i:[a-z0-9]|[a-z0-9][a-z0-9-]{0,61}[a-z0-9])$`)
+)
+
+// OneOf - the string must be one of values
+func OneOf(values ...string) tfsdk.AttributeValidator {
+	quoted := []string{}
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return stringValidator{
+		description: fmt.Sprintf("value must be one of: %s", strings.Join(quoted, ", ")),
+		valid: func(value string) bool {
+			for _, v := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// Matches - the string must match re, described to users as e.g. "value must be formatted hh24:mi"
+func Matches(re *regexp.Regexp, description string) tfsdk.AttributeValidator {
+	return stringValidator{description: description, valid: re.MatchString}
+}
+
+// TimeRange - a daily UTC time range formatted hh24:mi-hh24:mi, e.g. 00:00-03:00
+func TimeRange() tfsdk.AttributeValidator {
+	return Matches(timeRangeRegexp, "value must be a UTC time range formatted hh24:mi-hh24:mi, e.g. 00:00-03:00")
+}
+
+// WeeklyTimeRange - a weekly UTC time range formatted ddd:hh24:mi-ddd:hh24:mi, e.g. sun:05:00-sun:09:00
+func WeeklyTimeRange() tfsdk.AttributeValidator {
+	return Matches(
+		weeklyTimeRangeRegexp,
+		"value must be a UTC time range formatted ddd:hh24:mi-ddd:hh24:mi, e.g. sun:05:00-sun:09:00",
+	)
+}
+
+// NotEmpty - the string must not be empty
+func NotEmpty() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must not be empty",
+		valid:       func(value string) bool { return value != "" },
+	}
+}
+
+// FQDN - the string must be a fully qualified domain name, e.g. app.example.com
+func FQDN() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be a fully qualified domain name, e.g. app.example.com",
+		valid:       func(value string) bool { return isFQDN(value) },
+	}
+}
+
+// WildcardFQDN - the string must be a fully qualified domain name, optionally a wildcard one like
+// *.example.com as certificates allow
+func WildcardFQDN() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be a fully qualified domain name, e.g. app.example.com or *.example.com",
+		valid:       func(value string) bool { return isFQDN(strings.TrimPrefix(value, "*.")) },
+	}
+}
+
+// ARN - the string must be the arn of a resource of the aws service, e.g. acm or secretsmanager
+func ARN(service string) tfsdk.AttributeValidator {
+	return Matches(
+		regexp.MustCompile(`^arn:aws(-[a-z]+)*:`+regexp.QuoteMeta(service)+`:[a-z0-9-]+:\d{12}:.+$`),
+		fmt.Sprintf("value must be the arn of an aws %s resource, e.g. arn:aws:%s:us-east-1:123456789012:...", service, service),
+	)
+}
+
+// JSONObject - the string must be a json object, e.g. the output of jsonencode({ name = "db" })
+func JSONObject() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be a json object, e.g. jsonencode({ name = \"db\" })",
+		valid: func(value string) bool {
+			object := map[string]interface{}{}
+			return json.Unmarshal([]byte(value), &object) == nil
+		},
+	}
+}
+
+// IntBetween - the number must be a whole number from min to max, both included
+func IntBetween(min, max int64) tfsdk.AttributeValidator {
+	return intBetweenValidator{min: min, max: max}
+}
+
+// isFQDN - at least two labels and no longer than a domain name can be, a trailing dot is allowed
+func isFQDN(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	labels := strings.Split(value, ".")
+	if len(value) > 253 || len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !domainLabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringValidator - a check of a string attribute, null and unknown values are left for later
+type stringValidator struct {
+	description string
+	valid       func(value string) bool
+}
+
+func (v stringValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+func (v stringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	if !v.valid(value.Value) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid attribute value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.AttributePath, v.Description(ctx), value.Value),
+		)
+	}
+}
+
+// intBetweenValidator - a range check of an int64 or number attribute
+type intBetweenValidator struct {
+	min, max int64
+}
+
+func (v intBetweenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a whole number between %d and %d", v.min, v.max)
+}
+
+func (v intBetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v intBetweenValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var number *big.Float
+	switch value := req.AttributeConfig.(type) {
+	case types.Int64:
+		if value.Null || value.Unknown {
+			return
+		}
+		number = new(big.Float).SetInt64(value.Value)
+	case types.Number:
+		if value.Null || value.Unknown || value.Value == nil {
+			return
+		}
+		number = value.Value
+	default:
+		return
+	}
+
+	i, accuracy := number.Int64()
+	if accuracy != big.Exact || i < v.min || i > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid attribute value",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.AttributePath, v.Description(ctx), number.Text('g', -1)),
+		)
+	}
+}
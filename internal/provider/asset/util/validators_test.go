@@ -0,0 +1,123 @@
+package assetutil
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func validate(v tfsdk.AttributeValidator, value attr.Value) *tfsdk.ValidateAttributeResponse {
+	resp := &tfsdk.ValidateAttributeResponse{}
+	v.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+		AttributePath:   path.Root("attribute"),
+		AttributeConfig: value,
+	}, resp)
+	return resp
+}
+
+func TestStringValidators(t *testing.T) {
+	for name, tc := range map[string]struct {
+		validator tfsdk.AttributeValidator
+		values    map[string]bool
+	}{
+		"one of": {
+			validator: OneOf("postgres", "mysql", "mariadb"),
+			values:    map[string]bool{"postgres": true, "mariadb": true, "Postgres": false, "oracle": false, "": false},
+		},
+		"time range": {
+			validator: TimeRange(),
+			values: map[string]bool{
+				"00:00-03:00":         true,
+				"22:30-23:59":         true,
+				"24:00-01:00":         false,
+				"00:60-01:00":         false,
+				"0:00-3:00":           false,
+				"sun:05:00-sun:09:00": false,
+			},
+		},
+		"weekly time range": {
+			validator: WeeklyTimeRange(),
+			values: map[string]bool{
+				"sun:05:00-sun:09:00": true,
+				"Sat:23:00-sun:01:30": true,
+				"sun:05:00-09:00":     false,
+				"sunday:05:00-sun:09": false,
+				"xyz:05:00-sun:09:00": false,
+			},
+		},
+		"not empty": {
+			validator: NotEmpty(),
+			values:    map[string]bool{"db": true, "": false},
+		},
+		"fqdn": {
+			validator: FQDN(),
+			values: map[string]bool{
+				"app.example.com":  true,
+				"example.com.":     true,
+				"a-b.example.com":  true,
+				"example":          false,
+				"-app.example.com": false,
+				"*.example.com":    false,
+				"app..example.com": false,
+				"app example.com":  false,
+			},
+		},
+		"wildcard fqdn": {
+			validator: WildcardFQDN(),
+			values:    map[string]bool{"*.example.com": true, "app.example.com": true, "*.com": false, "app.*.com": false},
+		},
+		"arn": {
+			validator: ARN("acm"),
+			values: map[string]bool{
+				"arn:aws:acm:us-east-1:123456789012:certificate/abc":            true,
+				"arn:aws-us-gov:acm:us-gov-west-1:123456789012:certificate/abc": true,
+				"arn:aws:secretsmanager:us-east-1:123456789012:secret:abc":      false,
+				"arn:aws:acm:us-east-1:1234:certificate/abc":                    false,
+				"certificate/abc": false,
+			},
+		},
+		"json object": {
+			validator: JSONObject(),
+			values:    map[string]bool{`{"name":"db"}`: true, `{}`: true, `["db"]`: false, `not json`: false},
+		},
+	} {
+		for value, valid := range tc.values {
+			resp := validate(tc.validator, types.String{Value: value})
+			assert.Equal(t, !valid, resp.Diagnostics.HasError(), "%s: %q", name, value)
+		}
+
+		// unknown values are checked once they are known, null ones are left to Required
+		assert.False(t, validate(tc.validator, types.String{Unknown: true}).Diagnostics.HasError(), name)
+		assert.False(t, validate(tc.validator, types.String{Null: true}).Diagnostics.HasError(), name)
+	}
+
+	resp := validate(OneOf("DNS", "EMAIL"), types.String{Value: "HTTP"})
+	assert.Equal(t, `Attribute attribute value must be one of: "DNS", "EMAIL", got: "HTTP"`, resp.Diagnostics[0].Detail())
+}
+
+func TestIntBetween(t *testing.T) {
+	v := IntBetween(1, 65535)
+	for value, valid := range map[float64]bool{
+		80:    true,
+		1:     true,
+		65535: true,
+		0:     false,
+		65536: false,
+		-80:   false,
+		80.5:  false,
+	} {
+		resp := validate(v, types.Number{Value: big.NewFloat(value)})
+		assert.Equal(t, !valid, resp.Diagnostics.HasError(), value)
+	}
+
+	assert.False(t, validate(v, types.Int64{Value: 443}).Diagnostics.HasError())
+	assert.True(t, validate(v, types.Int64{Value: 70000}).Diagnostics.HasError())
+	assert.False(t, validate(v, types.Number{Unknown: true}).Diagnostics.HasError())
+	assert.False(t, validate(v, types.Number{Null: true}).Diagnostics.HasError())
+}
@@ -0,0 +1,84 @@
+package assetutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+// AssetVersion - the planned bundle version, DefaultAssetVersion when it's left to be computed
+func AssetVersion(version types.String) string {
+	if version.Null || version.Unknown || version.Value == "" {
+		return DefaultAssetVersion
+	}
+	return version.Value
+}
+
+// ValidateAssetVersion - error unless the environment allows the version of the platform/assetType bundle
+func ValidateAssetVersion(ctx context.Context, c client.CloudClient, orgId, envId, platform, assetType, version string) error {
+	bundles, err := c.ListAssetBundles(ctx, orgId, envId)
+	if err != nil {
+		return fmt.Errorf("could not list the asset bundles of environment %s: %w", envId, err)
+	}
+
+	found := false
+	versions := []string{}
+	for _, bundle := range bundles {
+		if p, t, _ := client.SplitAsset(bundle.Identifier); p != platform || t != assetType {
+			continue
+		}
+		found = true
+		if slices.Contains(bundle.Types, version) {
+			return nil
+		}
+		versions = append(versions, bundle.Types...)
+	}
+
+	if !found {
+		return fmt.Errorf("environment %s does not allow %s %s assets", envId, platform, assetType)
+	}
+	return fmt.Errorf("version %q of the %s %s bundle is not available in environment %s, available versions: %v", version, platform, assetType, envId, versions)
+}
+
+// ValidatePlanAssetVersion - check a changed asset_version against the bundles allowed in the environment
+// while planning, so an unknown version fails before anything is applied
+func ValidatePlanAssetVersion(ctx context.Context, c client.CloudClient, platform, assetType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed or the provider isn't configured yet
+	if req.Plan.Raw.IsNull() || c == nil {
+		return
+	}
+
+	var version, orgId, envId types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("asset_version"), &version)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("organization_id"), &orgId)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("environment_id"), &envId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if version.Null || version.Unknown || orgId.Unknown || envId.Unknown {
+		return
+	}
+
+	// a version already deployed is not checked again, the bundle may have retired it since
+	if !req.State.Raw.IsNull() {
+		var prior types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("asset_version"), &prior)...)
+		if resp.Diagnostics.HasError() || prior.Equal(version) {
+			return
+		}
+	}
+
+	if err := ValidateAssetVersion(ctx, c, orgId.Value, envId.Value, platform, assetType, version.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("asset_version"),
+			"Invalid asset_version",
+			err.Error(),
+		)
+	}
+}
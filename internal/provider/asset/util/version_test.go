@@ -0,0 +1,39 @@
+package assetutil
+
+import (
+	"context"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestAssetVersion(t *testing.T) {
+	assert.Equal(t, DefaultAssetVersion, AssetVersion(types.String{Null: true}))
+	assert.Equal(t, DefaultAssetVersion, AssetVersion(types.String{Unknown: true}))
+	assert.Equal(t, DefaultAssetVersion, AssetVersion(types.String{Value: ""}))
+	assert.Equal(t, "v2", AssetVersion(types.String{Value: "v2"}))
+}
+
+func TestValidateAssetVersion(t *testing.T) {
+	c, env := assettest.Setup(t)
+	ctx := context.Background()
+	orgId := env.Organization.Id
+	c.Bundles = []cac.AssetBundle{
+		{Identifier: client.CompileAsset("aws", "rds", "latest"), Name: "rds", Types: []string{"latest", "v1", "v2"}},
+	}
+
+	assert.NoError(t, ValidateAssetVersion(ctx, c, orgId, env.Id, "aws", "rds", "v1"))
+	assert.NoError(t, ValidateAssetVersion(ctx, c, orgId, env.Id, "aws", "rds", "latest"))
+
+	err := ValidateAssetVersion(ctx, c, orgId, env.Id, "aws", "rds", "v3")
+	assert.ErrorContains(t, err, `version "v3" of the aws rds bundle is not available`)
+	assert.ErrorContains(t, err, "[latest v1 v2]")
+
+	err = ValidateAssetVersion(ctx, c, orgId, env.Id, "aws", "vpc", "latest")
+	assert.ErrorContains(t, err, "does not allow aws vpc assets")
+}
@@ -0,0 +1,319 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.ResourceWithImportState = &ConnectionResource{}
+var _ resource.ResourceWithModifyPlan = &ConnectionResource{}
+
+func NewResource() resource.Resource {
+	return &ConnectionResource{}
+}
+
+type ConnectionResource struct {
+	client   client.CloudClient
+	provider *util.ProviderData
+}
+
+func (r ConnectionResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	// the api can't update a connection, changing any of its ends replaces it
+	requiresReplace := tfsdk.AttributePlanModifiers{resource.RequiresReplace()}
+	useState := tfsdk.AttributePlanModifiers{resource.UseStateForUnknown()}
+	// ids left to the provider keep the one in state, a change of the provider's doesn't move the connection
+	defaultsReplace := tfsdk.AttributePlanModifiers{resource.UseStateForUnknown(), resource.RequiresReplace()}
+
+	return tfsdk.Schema{
+		MarkdownDescription: "A connection from an asset, e.g. a service, to an outgoing asset it depends on, " +
+			"e.g. a database. It is managed independently of the `connects_to` list of the asset.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.StringType,
+				Computed:      true,
+				PlanModifiers: useState,
+			},
+			"organization_id": {
+				Description:   "Defaults to the organization_id of the provider",
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: defaultsReplace,
+			},
+			"environment_id": {
+				Description:   "Defaults to the environment_id of the provider",
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: defaultsReplace,
+			},
+			"asset_id": {
+				Description:   "Asset the connection is made from, e.g. an ecs service",
+				Type:          types.StringType,
+				Required:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"outgoing_asset_id": {
+				Description:   "Asset the connection is made to, e.g. a database",
+				Type:          types.StringType,
+				Required:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"status": {
+				Type:          types.StringType,
+				Computed:      true,
+				PlanModifiers: useState,
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": {
+				Description: "Limits on how long the provider waits for the connection to be active",
+				NestingMode: tfsdk.BlockNestingModeSingle,
+				Attributes: map[string]tfsdk.Attribute{
+					"create": {
+						Description: fmt.Sprintf(
+							"How long to wait for the connection to be active, as a duration like \"30m\" or \"1h30m\" (default: %s)",
+							activeTimeout,
+						),
+						Type:       types.StringType,
+						Optional:   true,
+						Validators: []tfsdk.AttributeValidator{assetutil.Duration()},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *ConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+func (r *ConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.provider = data
+}
+
+// ModifyPlan - fall back to the provider's organization_id and environment_id when the resource omits them
+func (r *ConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.SetPlanDefaults(ctx, r.provider, map[string]string{
+		"organization_id": "organization_id",
+		"environment_id":  "environment_id",
+	}, req, resp)
+}
+
+func (r *ConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.CreateConnection(
+		ctx,
+		plan.OrganizationId.Value,
+		plan.EnvironmentId.Value,
+		plan.AssetId.Value,
+		cac.ConnectionInput{OutgoingAssetId: plan.OutgoingAssetId.Value},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connection",
+			"Could not create connection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Info(ctx, "created connection", map[string]interface{}{"id": conn.Id, "status": conn.Status})
+
+	// save the connection right away so a failure below doesn't leave it untracked
+	diags = resp.State.Set(ctx, connectionOutputToState(plan, conn))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	active, err := util.WaitForConnectionActive(
+		r.client,
+		ctx,
+		plan.OrganizationId.Value,
+		plan.EnvironmentId.Value,
+		plan.AssetId.Value,
+		conn.Id,
+		createTimeout(plan.Timeouts),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for connection",
+			fmt.Sprintf("Error when waiting for connection %s: %s", conn.Id, err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, connectionOutputToState(plan, active))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(
+		ctx,
+		state.OrganizationId.Value,
+		state.EnvironmentId.Value,
+		state.AssetId.Value,
+		state.ID.Value,
+	)
+	if client.IsNotFound(err) || (err == nil && conn.Status == cac.CONNECTIONSTATUS_DELETED) {
+		tflog.Warn(ctx, "Connection no longer exists, removing it from state", map[string]interface{}{"id": state.ID.Value})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading connection",
+			fmt.Sprintf("Error when reading connection %s: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, connectionOutputToState(state, conn))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update - every configurable attribute requires replacement, so there is never anything to send
+func (r *ConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DestroyConnection(
+		ctx,
+		state.OrganizationId.Value,
+		state.EnvironmentId.Value,
+		state.AssetId.Value,
+		state.ID.Value,
+	)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting connection",
+			fmt.Sprintf("Could not delete connection %s: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// always found in the following format: "{organization_id},{environment_id},{asset_id},{connection_id}"
+	positionalKeys := []string{"organization_id", "environment_id", "asset_id", "connection_id"}
+	ids := strings.Split(req.ID, ",")
+	if len(ids) != len(positionalKeys) {
+		resp.Diagnostics.AddError(
+			"Error insufficient values to import state",
+			fmt.Sprintf("Error unpacking values required for importing state for a connection: Got %d values in csv, expected %d", len(ids), len(positionalKeys)),
+		)
+		return
+	}
+	for idx, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Error invalid uuid provided to import state",
+				fmt.Sprintf("Error in trying to parse uuid (id for %s) from CSV-delimited request: %s",
+					positionalKeys[idx], err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	conn, err := r.client.GetConnection(ctx, ids[0], ids[1], ids[2], ids[3])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading connection",
+			fmt.Sprintf("Error when reading connection %s: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	// outgoing_asset_id is required, importing without it would plan to replace the connection
+	if conn.OutgoingConnectionAsset == nil {
+		resp.Diagnostics.AddError(
+			"Error importing connection",
+			fmt.Sprintf("Connection %s does not report its outgoing asset, so outgoing_asset_id can't be imported", req.ID),
+		)
+		return
+	}
+
+	prior := ResourceModel{
+		OrganizationId:  types.String{Value: ids[0]},
+		EnvironmentId:   types.String{Value: ids[1]},
+		AssetId:         types.String{Value: ids[2]},
+		OutgoingAssetId: types.String{Null: true},
+	}
+	diags := resp.State.Set(ctx, connectionOutputToState(prior, conn))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,159 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fake"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// deployedAsset - create an asset of the given bundle and wait for it to be deployed
+func deployedAsset(t *testing.T, c *fake.Client, env cac.EnvironmentOutput, bundle string) cac.AssetOutput {
+	t.Helper()
+
+	asset, err := c.CreateAsset(context.Background(), env.Organization.Id, env.Id, cac.AssetInput{Asset: bundle})
+	assert.NoError(t, err)
+	deployed, err := util.WaitForAssetOperation(c, context.Background(), env.Organization.Id, env.Id, asset.Id, asset.OperationId, time.Time{}, 0)
+	assert.NoError(t, err)
+	return *deployed
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	service := deployedAsset(t, c, env, "aws__ecs_web__latest")
+	database := deployedAsset(t, c, env, "aws__rds__latest")
+
+	plan := ResourceModel{
+		ID:              types.String{Unknown: true},
+		OrganizationId:  types.String{Value: env.Organization.Id},
+		EnvironmentId:   types.String{Value: env.Id},
+		AssetId:         types.String{Value: service.Id},
+		OutgoingAssetId: types.String{Value: database.Id},
+		Status:          types.String{Unknown: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.ID.Value)
+	assert.Equal(t, string(cac.CONNECTIONSTATUS_ACTIVE), state.Status.Value)
+	assert.Equal(t, database.Id, state.OutgoingAssetId.Value)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	imported := assettest.ImportState(t, r, fmt.Sprintf("%s,%s,%s,%s", env.Organization.Id, env.Id, service.Id, state.ID.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	// a deleted connection is dropped from state on the next refresh
+	read = assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	assert.True(t, assettest.IsRemoved(read.State))
+}
+
+func TestResourceImportStateRejectsMalformedId(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	imported := assettest.ImportState(t, r, fmt.Sprintf("%s,%s,not-a-uuid", env.Organization.Id, env.Id))
+	assert.True(t, imported.Diagnostics.HasError())
+}
+
+// withoutOutgoingAsset - a client whose connections don't report their outgoing asset
+type withoutOutgoingAsset struct {
+	*fake.Client
+}
+
+func (c withoutOutgoingAsset) GetConnection(ctx context.Context, orgId, envId, assetId, connectionId string) (*cac.ConnectionOutput, error) {
+	conn, err := c.Client.GetConnection(ctx, orgId, envId, assetId, connectionId)
+	if conn != nil {
+		conn.OutgoingConnectionAsset = nil
+	}
+	return conn, err
+}
+
+func TestResourceImportStateRequiresOutgoingAsset(t *testing.T) {
+	c, env := assettest.Setup(t)
+	service := deployedAsset(t, c, env, "aws__ecs_web__latest")
+	database := deployedAsset(t, c, env, "aws__rds__latest")
+	conn, err := c.CreateConnection(context.Background(), env.Organization.Id, env.Id, service.Id, cac.ConnectionInput{OutgoingAssetId: database.Id})
+	assert.NoError(t, err)
+
+	r := NewResource()
+	assettest.Configure(t, r, withoutOutgoingAsset{c})
+
+	imported := assettest.ImportState(t, r, fmt.Sprintf("%s,%s,%s,%s", env.Organization.Id, env.Id, service.Id, conn.Id))
+	if assert.True(t, imported.Diagnostics.HasError()) {
+		assert.Contains(t, imported.Diagnostics.Errors()[0].Detail(), "outgoing_asset_id")
+	}
+}
+
+// pendingConnections - a client whose connections never become active
+type pendingConnections struct {
+	*fake.Client
+}
+
+func (c pendingConnections) GetConnection(ctx context.Context, orgId, envId, assetId, connectionId string) (*cac.ConnectionOutput, error) {
+	conn, err := c.Client.GetConnection(ctx, orgId, envId, assetId, connectionId)
+	if conn != nil {
+		conn.Status = cac.CONNECTIONSTATUS_PENDING
+	}
+	return conn, err
+}
+
+func TestResourceCreateTimesOut(t *testing.T) {
+	c, env := assettest.Setup(t)
+	service := deployedAsset(t, c, env, "aws__ecs_web__latest")
+	database := deployedAsset(t, c, env, "aws__rds__latest")
+
+	r := NewResource()
+	assettest.Configure(t, r, pendingConnections{c})
+
+	plan := ResourceModel{
+		ID:              types.String{Unknown: true},
+		OrganizationId:  types.String{Value: env.Organization.Id},
+		EnvironmentId:   types.String{Value: env.Id},
+		AssetId:         types.String{Value: service.Id},
+		OutgoingAssetId: types.String{Value: database.Id},
+		Status:          types.String{Unknown: true},
+		Timeouts:        &Timeouts{Create: types.String{Value: "20ms"}},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assert.True(t, created.Diagnostics.HasError())
+	// the connection is kept in state even though it isn't active yet
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.ID.Value)
+	assert.Equal(t, string(cac.CONNECTIONSTATUS_PENDING), state.Status.Value)
+	assert.Equal(t, plan.Timeouts, state.Timeouts)
+}
+
+func TestCreateTimeout(t *testing.T) {
+	assert.Equal(t, activeTimeout, createTimeout(nil))
+	assert.Equal(t, activeTimeout, createTimeout(&Timeouts{Create: types.String{Null: true}}))
+	assert.Equal(t, 90*time.Minute, createTimeout(&Timeouts{Create: types.String{Value: "1h30m"}}))
+}
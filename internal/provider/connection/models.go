@@ -0,0 +1,57 @@
+package connection
+
+import (
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+)
+
+// activeTimeout - how long creating a connection waits for it to be active when timeouts.create is not set
+const activeTimeout = time.Hour
+
+// ResourceModel - the state of an aptible_connection resource
+type ResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	OrganizationId  types.String `tfsdk:"organization_id"`
+	EnvironmentId   types.String `tfsdk:"environment_id"`
+	AssetId         types.String `tfsdk:"asset_id"`
+	OutgoingAssetId types.String `tfsdk:"outgoing_asset_id"`
+	Status          types.String `tfsdk:"status"`
+	Timeouts        *Timeouts    `tfsdk:"timeouts"`
+}
+
+// Timeouts - the `timeouts` block of an aptible_connection resource, nil when the block is not configured.
+// Only creating a connection waits on the backend
+type Timeouts struct {
+	Create types.String `tfsdk:"create"`
+}
+
+// createTimeout - the configured create timeout, or activeTimeout
+func createTimeout(t *Timeouts) time.Duration {
+	if t == nil {
+		return activeTimeout
+	}
+	return assetutil.ParseTimeout(t.Create, activeTimeout)
+}
+
+// connectionOutputToState - the ids of the organization, environment and assets are carried over from
+// prior, the api only reports them nested in the connected assets which it may leave out. So are the timeouts
+func connectionOutputToState(prior ResourceModel, conn *cac.ConnectionOutput) ResourceModel {
+	state := ResourceModel{
+		ID:              types.String{Value: conn.Id},
+		OrganizationId:  prior.OrganizationId,
+		EnvironmentId:   prior.EnvironmentId,
+		AssetId:         prior.AssetId,
+		OutgoingAssetId: prior.OutgoingAssetId,
+		Status:          types.String{Value: string(conn.Status)},
+		Timeouts:        prior.Timeouts,
+	}
+
+	if outgoing := conn.OutgoingConnectionAsset; outgoing != nil {
+		state.OutgoingAssetId = types.String{Value: outgoing.Id}
+	}
+	return state
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetBundlesDataSource(t *testing.T) {
+	_, env := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "aptible_asset_bundles" "env" {
+  organization_id = %q
+  environment_id  = %q
+}
+`, env.Organization.Id, env.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.aptible_asset_bundles.env", "identifiers.*", "aws__rds__latest"),
+					resource.TestCheckResourceAttr("data.aptible_asset_bundles.env", "bundles.#", "8"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetsDataSource(t *testing.T) {
+	_, env := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "aptible_aws_vpc" "network" {
+  organization_id = %[1]q
+  environment_id  = %[2]q
+  name            = "network"
+}
+
+data "aptible_assets" "vpcs" {
+  organization_id = %[1]q
+  environment_id  = %[2]q
+  asset_type      = "vpc"
+  name            = aptible_aws_vpc.network.name
+}
+`, env.Organization.Id, env.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_assets.vpcs", "assets.#", "1"),
+					resource.TestCheckResourceAttrPair("data.aptible_assets.vpcs", "assets.0.id", "aptible_aws_vpc.network", "id"),
+					resource.TestCheckResourceAttr("data.aptible_assets.vpcs", "assets.0.status", "DEPLOYED"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRdsDataSource(t *testing.T) {
+	_, env := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "aptible_aws_rds" "db" {
+  organization_id = %[1]q
+  environment_id  = %[2]q
+  vpc_name        = "network"
+  name            = "db"
+  engine          = "postgres"
+  engine_version  = "14"
+}
+
+data "aptible_aws_rds" "db" {
+  organization_id = %[1]q
+  environment_id  = %[2]q
+  name            = aptible_aws_rds.db.name
+}
+`, env.Organization.Id, env.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.aptible_aws_rds.db", "id", "aptible_aws_rds.db", "id"),
+					resource.TestCheckResourceAttrPair("data.aptible_aws_rds.db", "uri_secret_arn", "aptible_aws_rds.db", "uri_secret_arn"),
+					resource.TestCheckResourceAttr("data.aptible_aws_rds.db", "engine", "postgres"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOperationsDataSource(t *testing.T) {
+	_, env := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "aptible_aws_vpc" "network" {
+  organization_id = %[1]q
+  environment_id  = %[2]q
+  name            = "network"
+}
+
+data "aptible_operations" "network" {
+  organization_id = %[1]q
+  asset_id        = aptible_aws_vpc.network.id
+}
+`, env.Organization.Id, env.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_operations.network", "operations.#", "1"),
+					resource.TestCheckResourceAttr("data.aptible_operations.network", "operations.0.operation_type", "CREATE"),
+					resource.TestCheckResourceAttr("data.aptible_operations.network", "operations.0.status", "COMPLETE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLookupByName(t *testing.T) {
+	_, env := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "aptible_organization" "org" {
+  name = %q
+}
+
+data "aptible_environment" "env" {
+  name   = %q
+  org_id = data.aptible_organization.org.id
+}
+`, env.Organization.Name, env.Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_organization.org", "id", env.Organization.Id),
+					resource.TestCheckResourceAttr("data.aptible_environment.env", "id", env.Id),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEnvironmentsDataSource(t *testing.T) {
+	_, env := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "aptible_organizations" "all" {
+  name_regex = %q
+}
+
+data "aptible_environments" "all" {
+  org_id = data.aptible_organizations.all.ids[0]
+}
+`, "^"+env.Organization.Name+"$"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aptible_organizations.all", "organizations.#", "1"),
+					resource.TestCheckResourceAttr("data.aptible_environments.all", "environments.#", "1"),
+					resource.TestCheckResourceAttr("data.aptible_environments.all", "environments.0.id", env.Id),
+					resource.TestCheckResourceAttr("data.aptible_environments.all", "environments.0.aws_account_id", *env.AwsAccountId),
+				),
+			},
+		},
+	})
+}
@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 
+	cac "github.com/aptible/cloud-api-clients/clients/go"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
-var _ datasource.DataSource = &EnvDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &EnvDataSource{}
 
 func NewDataSource() datasource.DataSource {
 	return &EnvDataSource{}
@@ -25,18 +28,22 @@ type EnvDataSource struct {
 
 func (r EnvDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
+		MarkdownDescription: "An environment of an organization, looked up by either its `id` or its `name`",
 		Attributes: map[string]tfsdk.Attribute{
 			"id": {
 				Type:     types.StringType,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"org_id": {
 				Type:     types.StringType,
 				Required: true,
 			},
 			"name": {
-				Type:     types.StringType,
-				Computed: true,
+				Description: "Name of the environment, which must be unique in the organization to look it up by",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
 			},
 			"aws_account_id": {
 				Type:     types.StringType,
@@ -56,18 +63,35 @@ func (r *EnvDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(client.CloudClient)
+	data, ok := req.ProviderData.(*util.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected client.CloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+}
+
+func (r *EnvDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config Env
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.ID.Unknown || config.Name.Unknown {
+		return
+	}
+
+	if config.ID.Null == config.Name.Null {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid environment lookup",
+			"Exactly one of id or name must be set to look up an environment",
+		)
+	}
 }
 
 func (r *EnvDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,10 +103,16 @@ func (r *EnvDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
-	env, err := r.client.DescribeEnvironment(ctx, config.OrgID.Value, config.ID.Value)
+	var env *cac.EnvironmentOutput
+	var err error
+	if config.ID.Null {
+		env, err = findEnvironmentByName(ctx, r.client, config.OrgID.Value, config.Name.Value)
+	} else {
+		env, err = r.client.DescribeEnvironment(ctx, config.OrgID.Value, config.ID.Value)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error retrieving org",
+			"Error retrieving environment",
 			err.Error(),
 		)
 		return
@@ -92,7 +122,11 @@ func (r *EnvDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		ID:           types.String{Value: env.Id},
 		OrgID:        types.String{Value: env.Organization.Id},
 		Name:         types.String{Value: env.Name},
-		AwsAccountId: types.String{Value: *env.AwsAccountId},
+		AwsAccountId: types.String{Null: true},
+	}
+	// a newly created environment has no aws account until it's provisioned
+	if env.AwsAccountId != nil {
+		state.AwsAccountId = types.String{Value: *env.AwsAccountId}
 	}
 
 	tflog.Info(ctx, "Setting state for asset", map[string]interface{}{"state": state})
@@ -104,3 +138,31 @@ func (r *EnvDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 }
+
+// findEnvironmentByName - the one environment of the organization with the name
+func findEnvironmentByName(ctx context.Context, c client.CloudClient, orgId, name string) (*cac.EnvironmentOutput, error) {
+	envs, err := c.ListEnvironments(ctx, orgId)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []cac.EnvironmentOutput{}
+	for _, env := range envs {
+		if env.Name == name {
+			matches = append(matches, env)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no environment named %q found in organization %s", name, orgId)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := []string{}
+		for _, env := range matches {
+			ids = append(ids, env.Id)
+		}
+		return nil, fmt.Errorf("%d environments named %q found in organization %s, use id to pick one of: %v", len(matches), name, orgId, ids)
+	}
+}
@@ -0,0 +1,70 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestDataSourceLookup(t *testing.T) {
+	c, env := assettest.Setup(t)
+	orgId := env.Organization.Id
+	c.AddEnvironment(orgId, "twin")
+	c.AddEnvironment(orgId, "twin")
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := func(config Env) (Env, *datasource.ReadResponse) {
+		t.Helper()
+		config.OrgID = types.String{Value: orgId}
+		config.AwsAccountId = types.String{Null: true}
+		resp := assettest.ReadDataSource(t, d, config)
+		var state Env
+		if !resp.Diagnostics.HasError() {
+			assettest.Get(t, resp.State, &state)
+		}
+		return state, resp
+	}
+
+	byId, resp := read(Env{ID: types.String{Value: env.Id}, Name: types.String{Null: true}})
+	assettest.RequireNoError(t, resp.Diagnostics)
+	assert.Equal(t, "test-env", byId.Name.Value)
+
+	byName, resp := read(Env{ID: types.String{Null: true}, Name: types.String{Value: "test-env"}})
+	assettest.RequireNoError(t, resp.Diagnostics)
+	assert.Equal(t, byId, byName)
+
+	_, resp = read(Env{ID: types.String{Null: true}, Name: types.String{Value: "missing"}})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `no environment named "missing"`)
+
+	_, resp = read(Env{ID: types.String{Null: true}, Name: types.String{Value: "twin"}})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `2 environments named "twin"`)
+}
+
+func TestDataSourceValidateConfig(t *testing.T) {
+	d := NewDataSource()
+	config := Env{OrgID: types.String{Value: "org"}, AwsAccountId: types.String{Null: true}}
+
+	config.ID, config.Name = types.String{Value: "env"}, types.String{Null: true}
+	assert.False(t, assettest.ValidateDataSourceConfig(t, d, config).HasError())
+
+	config.ID, config.Name = types.String{Null: true}, types.String{Value: "staging"}
+	assert.False(t, assettest.ValidateDataSourceConfig(t, d, config).HasError())
+
+	config.ID, config.Name = types.String{Value: "env"}, types.String{Value: "staging"}
+	assert.True(t, assettest.ValidateDataSourceConfig(t, d, config).HasError())
+
+	config.ID, config.Name = types.String{Null: true}, types.String{Null: true}
+	assert.True(t, assettest.ValidateDataSourceConfig(t, d, config).HasError())
+
+	// not known until apply, e.g. set from a resource
+	config.ID, config.Name = types.String{Unknown: true}, types.String{Null: true}
+	assert.False(t, assettest.ValidateDataSourceConfig(t, d, config).HasError())
+}
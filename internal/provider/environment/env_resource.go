@@ -0,0 +1,307 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.ResourceWithImportState = &EnvResource{}
+var _ resource.ResourceWithModifyPlan = &EnvResource{}
+
+func NewResource() resource.Resource {
+	return &EnvResource{}
+}
+
+type EnvResource struct {
+	client   client.CloudClient
+	provider *util.ProviderData
+}
+
+func (r EnvResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "An environment of an organization, backed by its own aws account which is " +
+			"provisioned when the environment is created",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"org_id": {
+				Description: "Organization the environment belongs to, defaults to the organization_id of the provider",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"description": {
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"aws_account_id": {
+				Description: "Id of the aws account provisioned for the environment",
+				Type:        types.StringType,
+				Computed:    true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"data": {
+				Description: "Settings of the environment as a json object, e.g. jsonencode({ region = \"us-east-1\" }). " +
+					"They replace the settings the backend holds on every create and update, an empty object is sent when " +
+					"unset. The api doesn't return them, so an imported environment starts without any",
+				Type:       types.StringType,
+				Optional:   true,
+				Validators: []tfsdk.AttributeValidator{assetutil.JSONObject()},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": {
+				Description: "Limits on how long the provider waits for the environment to be provisioned",
+				NestingMode: tfsdk.BlockNestingModeSingle,
+				Attributes: map[string]tfsdk.Attribute{
+					"create": {
+						Description: fmt.Sprintf(
+							"How long to wait for the aws account of the environment, as a duration like \"30m\" or \"1h30m\" (default: %s)",
+							provisionTimeout,
+						),
+						Type:       types.StringType,
+						Optional:   true,
+						Validators: []tfsdk.AttributeValidator{assetutil.Duration()},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *EnvResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+func (r *EnvResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.provider = data
+}
+
+// ModifyPlan - fall back to the provider's organization_id as org_id when the resource omits it
+func (r *EnvResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.SetPlanDefaults(ctx, r.provider, map[string]string{
+		"org_id": "organization_id",
+	}, req, resp)
+}
+
+func (r *EnvResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, err := planToEnvironmentInput(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating environment",
+			"Could not create environment, invalid data: "+err.Error(),
+		)
+		return
+	}
+
+	env, err := r.client.CreateEnvironment(ctx, plan.OrgID.Value, input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating environment",
+			"Could not create environment, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Info(ctx, "created environment", map[string]interface{}{"id": env.Id})
+
+	// save the environment right away, it exists even if provisioning its account fails or times out
+	diags = resp.State.Set(ctx, environmentOutputToState(env, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provisioned, err := util.WaitForEnvironmentProvisioned(r.client, ctx, plan.OrgID.Value, env.Id, createTimeout(plan.Timeouts))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for environment",
+			fmt.Sprintf("Error when waiting for the aws account of environment %s: %s", env.Id, err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, environmentOutputToState(provisioned, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *EnvResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env, err := r.client.DescribeEnvironment(ctx, state.OrgID.Value, state.ID.Value)
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "Environment no longer exists, removing it from state", map[string]interface{}{"id": state.ID.Value})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment",
+			fmt.Sprintf("Error when reading environment %s: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, environmentOutputToState(env, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *EnvResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state ResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, err := planToEnvironmentInput(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating environment",
+			fmt.Sprintf("Could not update environment %s, invalid data: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	env, err := r.client.UpdateEnvironment(ctx, state.OrgID.Value, state.ID.Value, input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating environment",
+			fmt.Sprintf("Could not update environment %s: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, environmentOutputToState(env, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *EnvResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DestroyEnvironment(ctx, state.OrgID.Value, state.ID.Value)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting environment",
+			fmt.Sprintf("Could not delete environment %s: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *EnvResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// always found in the following format: "{org_id},{env_id}"
+	ids := strings.Split(req.ID, ",")
+	if len(ids) != 2 {
+		resp.Diagnostics.AddError(
+			"Error insufficient values to import state",
+			fmt.Sprintf("Error unpacking values required for importing state for an environment: Got %d values in csv, expected 2 (org_id,env_id)", len(ids)),
+		)
+		return
+	}
+	for idx, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Error invalid uuid provided to import state",
+				fmt.Sprintf("Error in trying to parse uuid (id for %s) from CSV-delimited request: %s",
+					[]string{"org_id", "env_id"}[idx], err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	env, err := r.client.DescribeEnvironment(ctx, ids[0], ids[1])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment",
+			fmt.Sprintf("Error when reading environment %s: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, environmentOutputToState(env, ResourceModel{Description: types.String{Null: true}}))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
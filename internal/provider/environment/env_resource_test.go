@@ -0,0 +1,199 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	c, env := assettest.Setup(t)
+	c.ProvisionPolls = 2
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		ID:           types.String{Unknown: true},
+		OrgID:        types.String{Value: env.Organization.Id},
+		Name:         types.String{Value: "staging"},
+		Description:  types.String{Null: true},
+		AwsAccountId: types.String{Unknown: true},
+		Data:         types.String{Value: `{"region":"us-east-1"}`},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.ID.Value)
+	assert.Equal(t, "staging", state.Name.Value)
+	assert.True(t, state.Description.Null)
+	// the resource waits for the account to be provisioned
+	assert.NotEmpty(t, state.AwsAccountId.Value)
+	assert.Equal(t, `{"region":"us-east-1"}`, state.Data.Value)
+	assert.Equal(t, map[string]interface{}{"region": "us-east-1"}, c.EnvironmentData(state.ID.Value))
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, state, refreshed)
+
+	planned := state
+	planned.Name = types.String{Value: "production"}
+	planned.Description = types.String{Value: "customer facing"}
+	updated := assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, "production", state.Name.Value)
+	assert.Equal(t, "customer facing", state.Description.Value)
+	assert.Equal(t, refreshed.AwsAccountId, state.AwsAccountId)
+	// renaming sends the configured data back rather than wiping it
+	assert.Equal(t, map[string]interface{}{"region": "us-east-1"}, c.EnvironmentData(state.ID.Value))
+
+	planned = state
+	planned.Data = types.String{Value: `{"region":"us-west-2","tier":"gold"}`}
+	updated = assettest.Update(t, r, state, planned)
+	assettest.RequireNoError(t, updated.Diagnostics)
+	assettest.Get(t, updated.State, &state)
+	assert.Equal(t, planned.Data, state.Data)
+	assert.Equal(t, map[string]interface{}{"region": "us-west-2", "tier": "gold"}, c.EnvironmentData(state.ID.Value))
+
+	imported := assettest.ImportState(t, r, fmt.Sprintf("%s,%s", state.OrgID.Value, state.ID.Value))
+	assettest.RequireNoError(t, imported.Diagnostics)
+	var importedState ResourceModel
+	assettest.Get(t, imported.State, &importedState)
+	// the api doesn't return the data
+	assert.True(t, importedState.Data.Null)
+	importedState.Data = state.Data
+	assert.Equal(t, state, importedState)
+
+	deleted := assettest.Delete(t, r, state)
+	assettest.RequireNoError(t, deleted.Diagnostics)
+	assert.True(t, assettest.IsRemoved(deleted.State))
+
+	_, err := c.DescribeEnvironment(context.Background(), state.OrgID.Value, state.ID.Value)
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestResourceCreateTimeout(t *testing.T) {
+	c, env := assettest.Setup(t)
+	c.ProvisionPolls = 1000000
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		ID:           types.String{Unknown: true},
+		OrgID:        types.String{Value: env.Organization.Id},
+		Name:         types.String{Value: "staging"},
+		Description:  types.String{Null: true},
+		AwsAccountId: types.String{Unknown: true},
+		Data:         types.String{Null: true},
+		Timeouts:     &Timeouts{Create: types.String{Value: "20ms"}},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assert.True(t, created.Diagnostics.HasError())
+	// the environment is kept in state even though its account isn't provisioned yet
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.NotEmpty(t, state.ID.Value)
+	assert.True(t, state.AwsAccountId.Null)
+	assert.Equal(t, plan.Timeouts, state.Timeouts)
+}
+
+func TestCreateTimeout(t *testing.T) {
+	assert.Equal(t, provisionTimeout, createTimeout(nil))
+	assert.Equal(t, provisionTimeout, createTimeout(&Timeouts{Create: types.String{Null: true}}))
+	assert.Equal(t, 90*time.Minute, createTimeout(&Timeouts{Create: types.String{Value: "1h30m"}}))
+}
+
+func TestResourceEmptyDescription(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	plan := ResourceModel{
+		ID:           types.String{Unknown: true},
+		OrgID:        types.String{Value: env.Organization.Id},
+		Name:         types.String{Value: "staging"},
+		Description:  types.String{Value: ""},
+		AwsAccountId: types.String{Unknown: true},
+		Data:         types.String{Null: true},
+	}
+
+	created := assettest.Create(t, r, plan)
+	assettest.RequireNoError(t, created.Diagnostics)
+	var state ResourceModel
+	assettest.Get(t, created.State, &state)
+	assert.Equal(t, plan.Description, state.Description)
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	var refreshed ResourceModel
+	assettest.Get(t, read.State, &refreshed)
+	assert.Equal(t, plan.Description, refreshed.Description)
+
+	// the api leaving it out
+	assert.Equal(t, plan.Description, environmentOutputToState(&cac.EnvironmentOutput{Id: state.ID.Value}, state).Description)
+	assert.True(t, environmentOutputToState(&cac.EnvironmentOutput{Id: state.ID.Value}, ResourceModel{Description: types.String{Null: true}}).Description.Null)
+}
+
+func TestResourceReadRemovesDeletedEnvironment(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	state := environmentOutputToState(&env, ResourceModel{})
+	assert.NoError(t, c.DestroyEnvironment(context.Background(), env.Organization.Id, env.Id))
+
+	read := assettest.Read(t, r, state)
+	assettest.RequireNoError(t, read.Diagnostics)
+	assert.True(t, assettest.IsRemoved(read.State))
+}
+
+func TestResourceImportStateRejectsMalformedId(t *testing.T) {
+	c, env := assettest.Setup(t)
+	r := NewResource()
+	assettest.Configure(t, r, c)
+
+	for _, id := range []string{env.Id, "org,env", env.Organization.Id + "," + env.Id + ",extra"} {
+		imported := assettest.ImportState(t, r, id)
+		assert.True(t, imported.Diagnostics.HasError(), id)
+	}
+}
+
+func TestResourceProviderOrganization(t *testing.T) {
+	r := NewResource()
+	assettest.ConfigureProvider(t, r, &util.ProviderData{OrganizationId: "org"})
+
+	config := ResourceModel{
+		ID:           types.String{Null: true},
+		OrgID:        types.String{Null: true},
+		Name:         types.String{Value: "staging"},
+		Description:  types.String{Null: true},
+		AwsAccountId: types.String{Null: true},
+		Data:         types.String{Null: true},
+	}
+	planned := config
+	planned.ID = types.String{Unknown: true}
+	planned.OrgID = types.String{Unknown: true}
+	planned.AwsAccountId = types.String{Unknown: true}
+
+	resp := assettest.ModifyPlan(t, r, config, planned)
+	assettest.RequireNoError(t, resp.Diagnostics)
+	var plan ResourceModel
+	assettest.Get(t, tfsdk.State(resp.Plan), &plan)
+	assert.Equal(t, "org", plan.OrgID.Value)
+	assert.True(t, plan.ID.Unknown)
+}
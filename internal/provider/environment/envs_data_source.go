@@ -0,0 +1,179 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSourceWithValidateConfig = &EnvsDataSource{}
+
+func NewEnvsDataSource() datasource.DataSource {
+	return &EnvsDataSource{}
+}
+
+type EnvsDataSource struct {
+	client client.CloudClient
+}
+
+func (r EnvsDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Every environment of an organization, sorted by name, e.g. to create the same assets " +
+			"in each with `for_each = { for env in data.aptible_environments.all.environments : env.name => env.id }`",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"org_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"name_regex": {
+				Description: "Only list the environments whose name matches this regular expression",
+				Type:        types.StringType,
+				Optional:    true,
+			},
+			"ids": {
+				Description: "Id of every listed environment",
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+			"environments": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"id": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"aws_account_id": {
+						Description: "Id of the aws account of the environment, null until it is provisioned",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *EnvsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environments"
+}
+
+func (r *EnvsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *EnvsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var nameRegex types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("name_regex"), &nameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || nameRegex.Null || nameRegex.Unknown {
+		return
+	}
+
+	if _, err := regexp.Compile(nameRegex.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			fmt.Sprintf("Could not compile %q: %s", nameRegex.Value, err.Error()),
+		)
+	}
+}
+
+func (r *EnvsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config EnvsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := regexp.Compile(config.NameRegex.Value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			fmt.Sprintf("Could not compile %q: %s", config.NameRegex.Value, err.Error()),
+		)
+		return
+	}
+
+	envs, err := r.client.ListEnvironments(ctx, config.OrgID.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving environments",
+			err.Error(),
+		)
+		return
+	}
+	slices.SortStableFunc(envs, func(a, b cac.EnvironmentOutput) bool {
+		return a.Name < b.Name
+	})
+
+	state := EnvsDataSourceModel{
+		ID:           config.OrgID,
+		OrgID:        config.OrgID,
+		NameRegex:    config.NameRegex,
+		Ids:          types.List{Elems: []attr.Value{}, ElemType: types.StringType},
+		Environments: []EnvSummary{},
+	}
+	for _, env := range envs {
+		if !nameRegex.MatchString(env.Name) {
+			continue
+		}
+
+		summary := EnvSummary{
+			ID:           types.String{Value: env.Id},
+			Name:         types.String{Value: env.Name},
+			AwsAccountId: types.String{Null: true},
+		}
+		if env.AwsAccountId != nil {
+			summary.AwsAccountId = types.String{Value: *env.AwsAccountId}
+		}
+		state.Ids.Elems = append(state.Ids.Elems, summary.ID)
+		state.Environments = append(state.Environments, summary)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
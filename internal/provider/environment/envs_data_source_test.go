@@ -0,0 +1,74 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestEnvsDataSource(t *testing.T) {
+	c, env := assettest.Setup(t)
+	orgId := env.Organization.Id
+	staging := c.AddEnvironment(orgId, "staging")
+	production := c.AddEnvironment(orgId, "production")
+	other := c.AddOrg("other-org")
+	c.AddEnvironment(other.Id, "elsewhere")
+
+	d := NewEnvsDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := func(nameRegex types.String) EnvsDataSourceModel {
+		t.Helper()
+		resp := assettest.ReadDataSource(t, d, EnvsDataSourceModel{
+			ID:        types.String{Null: true},
+			OrgID:     types.String{Value: orgId},
+			NameRegex: nameRegex,
+			Ids:       types.List{Null: true, ElemType: types.StringType},
+		})
+		assettest.RequireNoError(t, resp.Diagnostics)
+		var state EnvsDataSourceModel
+		assettest.Get(t, resp.State, &state)
+		return state
+	}
+
+	state := read(types.String{Null: true})
+	assert.Len(t, state.Environments, 3)
+	// sorted by name
+	assert.Equal(t, []string{"production", "staging", "test-env"}, []string{
+		state.Environments[0].Name.Value,
+		state.Environments[1].Name.Value,
+		state.Environments[2].Name.Value,
+	})
+	assert.Equal(t, production.Id, state.Environments[0].ID.Value)
+	assert.Equal(t, *production.AwsAccountId, state.Environments[0].AwsAccountId.Value)
+	assert.Equal(t, types.String{Value: production.Id}, state.Ids.Elems[0])
+
+	state = read(types.String{Value: "^stag"})
+	assert.Len(t, state.Environments, 1)
+	assert.Equal(t, staging.Id, state.Environments[0].ID.Value)
+	assert.Len(t, state.Ids.Elems, 1)
+
+	state = read(types.String{Value: "nothing"})
+	assert.Empty(t, state.Environments)
+	assert.Empty(t, state.Ids.Elems)
+}
+
+func TestEnvsDataSourceValidateConfig(t *testing.T) {
+	d := NewEnvsDataSource()
+	config := EnvsDataSourceModel{
+		ID:    types.String{Null: true},
+		OrgID: types.String{Value: "org"},
+		Ids:   types.List{Null: true, ElemType: types.StringType},
+	}
+
+	config.NameRegex = types.String{Value: "^prod-.*"}
+	assert.False(t, assettest.ValidateDataSourceConfig(t, d, config).HasError())
+
+	config.NameRegex = types.String{Value: "(unclosed"}
+	diags := assettest.ValidateDataSourceConfig(t, d, config)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), "(unclosed")
+}
@@ -1,6 +1,18 @@
 package environment
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	assetutil "github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/util"
+)
+
+// provisionTimeout - how long creating an environment waits for its aws account when timeouts.create is not set
+const provisionTimeout = time.Hour
 
 type Env struct {
 	ID           types.String `tfsdk:"id"`
@@ -8,3 +20,89 @@ type Env struct {
 	Name         types.String `tfsdk:"name"`
 	AwsAccountId types.String `tfsdk:"aws_account_id"`
 }
+
+// ResourceModel - the state of an aptible_environment resource
+type ResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	OrgID        types.String `tfsdk:"org_id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	AwsAccountId types.String `tfsdk:"aws_account_id"`
+	Data         types.String `tfsdk:"data"`
+	Timeouts     *Timeouts    `tfsdk:"timeouts"`
+}
+
+// Timeouts - the `timeouts` block of an aptible_environment resource, nil when the block is not configured.
+// Only creating an environment waits on the backend
+type Timeouts struct {
+	Create types.String `tfsdk:"create"`
+}
+
+// createTimeout - the configured create timeout, or provisionTimeout
+func createTimeout(t *Timeouts) time.Duration {
+	if t == nil {
+		return provisionTimeout
+	}
+	return assetutil.ParseTimeout(t.Create, provisionTimeout)
+}
+
+// planToEnvironmentInput - the api replaces the data of the environment with what is sent, so the configured
+// data is always sent, an empty object when it isn't configured
+func planToEnvironmentInput(plan ResourceModel) (cac.EnvironmentInput, error) {
+	input := cac.EnvironmentInput{
+		Name: plan.Name.Value,
+		Data: map[string]interface{}{},
+	}
+	if !plan.Description.Null && !plan.Description.Unknown {
+		input.Description = &plan.Description.Value
+	}
+	if !plan.Data.Null && !plan.Data.Unknown && plan.Data.Value != "" {
+		if err := json.Unmarshal([]byte(plan.Data.Value), &input.Data); err != nil {
+			return input, fmt.Errorf("data must be a json object: %w", err)
+		}
+	}
+	return input, nil
+}
+
+// environmentOutputToState - the api doesn't return the data of the environment, so data and timeouts are
+// kept from the plan or prior state, and neither an empty description
+func environmentOutputToState(env *cac.EnvironmentOutput, prior ResourceModel) ResourceModel {
+	state := ResourceModel{
+		ID:           types.String{Value: env.Id},
+		OrgID:        types.String{Value: env.Organization.Id},
+		Name:         types.String{Value: env.Name},
+		Description:  types.String{Null: true},
+		AwsAccountId: types.String{Null: true},
+		Data:         prior.Data,
+		Timeouts:     prior.Timeouts,
+	}
+	if state.Data.Unknown || (!state.Data.Null && state.Data.Value == "") {
+		state.Data = types.String{Null: true}
+	}
+	if env.Description != nil && *env.Description != "" {
+		state.Description = types.String{Value: *env.Description}
+	} else if !prior.Description.Null && !prior.Description.Unknown && prior.Description.Value == "" {
+		// the api leaves an empty description out, keep it as configured
+		state.Description = prior.Description
+	}
+	if env.AwsAccountId != nil {
+		state.AwsAccountId = types.String{Value: *env.AwsAccountId}
+	}
+	return state
+}
+
+// EnvsDataSourceModel - the state of an aptible_environments data source
+type EnvsDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	OrgID        types.String `tfsdk:"org_id"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Ids          types.List   `tfsdk:"ids"`
+	Environments []EnvSummary `tfsdk:"environments"`
+}
+
+// EnvSummary - an environment listed by the aptible_environments data source
+type EnvSummary struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	AwsAccountId types.String `tfsdk:"aws_account_id"`
+}
@@ -0,0 +1,77 @@
+package operation
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+type DataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	AssetId        types.String `tfsdk:"asset_id"`
+	Operations     []Operation  `tfsdk:"operations"`
+}
+
+// Operation - an operation run on the asset, e.g. its deploy
+type Operation struct {
+	ID            types.String `tfsdk:"id"`
+	OperationType types.String `tfsdk:"operation_type"`
+	Status        types.String `tfsdk:"status"`
+	UserId        types.String `tfsdk:"user_id"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+	Error         types.String `tfsdk:"error"`
+}
+
+// operationsToModel - the operations oldest first, so the last one is the latest. Those without a creation
+// time come first, operations created at the same time are ordered by id
+func operationsToModel(ops []client.Operation) []Operation {
+	sorted := slices.Clone(ops)
+	slices.SortFunc(sorted, operationBefore)
+
+	models := []Operation{}
+	for _, op := range sorted {
+		models = append(models, Operation{
+			ID:            types.String{Value: op.Id},
+			OperationType: optionalString(string(op.GetOperationType())),
+			Status:        optionalString(string(op.GetStatus())),
+			UserId:        optionalString(op.UserId),
+			CreatedAt:     timestamp(op.CreatedAt),
+			UpdatedAt:     timestamp(op.UpdatedAt),
+			Error:         optionalString(op.Error),
+		})
+	}
+	return models
+}
+
+// operationBefore - a strict order on operations for operationsToModel
+func operationBefore(a, b client.Operation) bool {
+	switch {
+	case a.CreatedAt == nil && b.CreatedAt != nil:
+		return true
+	case a.CreatedAt != nil && b.CreatedAt == nil:
+		return false
+	case a.CreatedAt != nil && !a.CreatedAt.Equal(*b.CreatedAt):
+		return a.CreatedAt.Before(*b.CreatedAt)
+	default:
+		return a.Id < b.Id
+	}
+}
+
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.String{Null: true}
+	}
+	return types.String{Value: value}
+}
+
+func timestamp(t *time.Time) types.String {
+	if t == nil {
+		return types.String{Null: true}
+	}
+	return types.String{Value: t.UTC().Format(time.RFC3339)}
+}
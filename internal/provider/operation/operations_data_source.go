@@ -0,0 +1,142 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &OperationsDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &OperationsDataSource{}
+}
+
+type OperationsDataSource struct {
+	client client.CloudClient
+}
+
+func (r OperationsDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "The operations run on an asset, oldest first, e.g. to check the outcome of its latest " +
+			"deploy with `element(data.aptible_operations.db.operations, length(data.aptible_operations.db.operations) - 1).status`",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"organization_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"asset_id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"operations": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"id": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"operation_type": {
+						Description: "What the operation did, e.g. CREATE, UPDATE or DESTROY",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"status": {
+						Description: "Status of the operation, e.g. IN_PROGRESS, COMPLETE or FAILED",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"user_id": {
+						Description: "User who started the operation",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"created_at": {
+						Description: "When the operation started, as an RFC 3339 timestamp",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"updated_at": {
+						Description: "When the operation last changed, as an RFC 3339 timestamp",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"error": {
+						Description: "Why the operation failed, null unless it did",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *OperationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_operations"
+}
+
+func (r *OperationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *OperationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ops, err := r.client.ListOperationsByAsset(ctx, config.OrganizationId.Value, config.AssetId.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving operations",
+			fmt.Sprintf("Could not list the operations of asset %s: %s", config.AssetId.Value, err.Error()),
+		)
+		return
+	}
+
+	state := DataSourceModel{
+		ID:             config.AssetId,
+		OrganizationId: config.OrganizationId,
+		AssetId:        config.AssetId,
+		Operations:     operationsToModel(ops),
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,111 @@
+package operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+func TestDataSourceOperations(t *testing.T) {
+	c, env := assettest.Setup(t)
+	ctx := context.Background()
+	orgId := env.Organization.Id
+
+	asset, err := c.CreateAsset(ctx, orgId, env.Id, cac.AssetInput{
+		Asset:           client.CompileAsset("aws", "vpc", "latest"),
+		AssetVersion:    "latest",
+		AssetParameters: map[string]interface{}{"name": "network"},
+	})
+	assert.NoError(t, err)
+	_, err = util.WaitForAssetOperation(c, ctx, orgId, env.Id, asset.Id, asset.OperationId, time.Time{}, 0)
+	assert.NoError(t, err)
+
+	_, err = c.UpdateAsset(ctx, asset.Id, env.Id, orgId, cac.AssetInput{
+		AssetVersion:    "latest",
+		AssetParameters: map[string]interface{}{"name": "network"},
+	})
+	assert.NoError(t, err)
+	c.FailAsset(asset.Id, "apply failed", "")
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	resp := assettest.ReadDataSource(t, d, DataSourceModel{
+		ID:             types.String{Null: true},
+		OrganizationId: types.String{Value: orgId},
+		AssetId:        types.String{Value: asset.Id},
+	})
+	assettest.RequireNoError(t, resp.Diagnostics)
+	var state DataSourceModel
+	assettest.Get(t, resp.State, &state)
+
+	assert.Equal(t, asset.Id, state.ID.Value)
+	assert.Len(t, state.Operations, 2)
+
+	created := state.Operations[0]
+	assert.Equal(t, *asset.OperationId, created.ID.Value)
+	assert.Equal(t, "CREATE", created.OperationType.Value)
+	assert.Equal(t, "COMPLETE", created.Status.Value)
+	assert.Equal(t, "fake-user", created.UserId.Value)
+	assert.True(t, created.Error.Null)
+	_, err = time.Parse(time.RFC3339, created.CreatedAt.Value)
+	assert.NoError(t, err)
+
+	updated := state.Operations[1]
+	assert.Equal(t, "UPDATE", updated.OperationType.Value)
+	assert.Equal(t, "FAILED", updated.Status.Value)
+	assert.Equal(t, "apply failed", updated.Error.Value)
+}
+
+func TestOperationsToModelOrder(t *testing.T) {
+	earlier := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Minute)
+
+	ops := []client.Operation{
+		{OperationOutput: cac.OperationOutput{Id: "second"}, OperationDetails: client.OperationDetails{CreatedAt: &later}},
+		{OperationOutput: cac.OperationOutput{Id: "first"}, OperationDetails: client.OperationDetails{CreatedAt: &earlier}},
+	}
+
+	models := operationsToModel(ops)
+	assert.Equal(t, "first", models[0].ID.Value)
+	assert.Equal(t, "2022-10-01T12:00:00Z", models[0].CreatedAt.Value)
+	assert.Equal(t, "second", models[1].ID.Value)
+	assert.True(t, models[1].UpdatedAt.Null)
+	assert.True(t, models[1].Status.Null)
+	// the api's order is left alone
+	assert.Equal(t, "second", ops[0].Id)
+}
+
+func TestOperationsToModelOrderWithoutCreatedAt(t *testing.T) {
+	earlier := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Minute)
+	op := func(id string, createdAt *time.Time) client.Operation {
+		return client.Operation{OperationOutput: cac.OperationOutput{Id: id}, OperationDetails: client.OperationDetails{CreatedAt: createdAt}}
+	}
+
+	ops := []client.Operation{
+		op("d", &later),
+		op("b", nil),
+		op("f", &later),
+		op("c", &earlier),
+		op("a", nil),
+		op("e", &later),
+	}
+
+	// the same order whichever way the api lists them
+	for _, listed := range [][]client.Operation{ops, {ops[5], ops[4], ops[3], ops[2], ops[1], ops[0]}} {
+		ids := []string{}
+		for _, model := range operationsToModel(listed) {
+			ids = append(ids, model.ID.Value)
+		}
+		assert.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, ids)
+	}
+}
@@ -6,3 +6,11 @@ type Org struct {
 	ID   types.String `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
 }
+
+// OrgsDataSourceModel - the state of an aptible_organizations data source
+type OrgsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	Ids           types.List   `tfsdk:"ids"`
+	Organizations []Org        `tfsdk:"organizations"`
+}
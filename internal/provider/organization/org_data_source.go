@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 
+	cac "github.com/aptible/cloud-api-clients/clients/go"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
-var _ datasource.DataSource = &OrgDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &OrgDataSource{}
 
 func NewDataSource() datasource.DataSource {
 	return &OrgDataSource{}
@@ -25,14 +28,18 @@ type OrgDataSource struct {
 
 func (r OrgDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
+		MarkdownDescription: "An organization, looked up by either its `id` or its `name`",
 		Attributes: map[string]tfsdk.Attribute{
 			"id": {
 				Type:     types.StringType,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"name": {
-				Type:     types.StringType,
-				Computed: true,
+				Description: "Name of the organization, which must be unique among the organizations the token can see to look it up by",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
 			},
 		},
 	}, nil
@@ -48,18 +55,35 @@ func (r *OrgDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(client.CloudClient)
+	data, ok := req.ProviderData.(*util.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected client.CloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+}
+
+func (r *OrgDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config Org
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.ID.Unknown || config.Name.Unknown {
+		return
+	}
+
+	if config.ID.Null == config.Name.Null {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid organization lookup",
+			"Exactly one of id or name must be set to look up an organization",
+		)
+	}
 }
 
 func (r *OrgDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -71,7 +95,13 @@ func (r *OrgDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
-	org, err := r.client.FindOrg(ctx, config.ID.Value)
+	var org *cac.OrganizationOutput
+	var err error
+	if config.ID.Null {
+		org, err = findOrgByName(ctx, r.client, config.Name.Value)
+	} else {
+		org, err = r.client.FindOrg(ctx, config.ID.Value)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving org",
@@ -94,3 +124,31 @@ func (r *OrgDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 }
+
+// findOrgByName - the one organization visible to the token with the name
+func findOrgByName(ctx context.Context, c client.CloudClient, name string) (*cac.OrganizationOutput, error) {
+	orgs, err := c.ListOrgs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []cac.OrganizationOutput{}
+	for _, org := range orgs {
+		if org.Name == name {
+			matches = append(matches, org)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no organization named %q found", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := []string{}
+		for _, org := range matches {
+			ids = append(ids, org.Id)
+		}
+		return nil, fmt.Errorf("%d organizations named %q found, use id to pick one of: %v", len(matches), name, ids)
+	}
+}
@@ -0,0 +1,55 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestDataSourceLookup(t *testing.T) {
+	c, env := assettest.Setup(t)
+	c.AddOrg("twin")
+	c.AddOrg("twin")
+
+	d := NewDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := func(config Org) (Org, *datasource.ReadResponse) {
+		t.Helper()
+		resp := assettest.ReadDataSource(t, d, config)
+		var state Org
+		if !resp.Diagnostics.HasError() {
+			assettest.Get(t, resp.State, &state)
+		}
+		return state, resp
+	}
+
+	byId, resp := read(Org{ID: types.String{Value: env.Organization.Id}, Name: types.String{Null: true}})
+	assettest.RequireNoError(t, resp.Diagnostics)
+	assert.Equal(t, "test-org", byId.Name.Value)
+
+	byName, resp := read(Org{ID: types.String{Null: true}, Name: types.String{Value: "test-org"}})
+	assettest.RequireNoError(t, resp.Diagnostics)
+	assert.Equal(t, byId, byName)
+
+	_, resp = read(Org{ID: types.String{Null: true}, Name: types.String{Value: "missing"}})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `no organization named "missing"`)
+
+	_, resp = read(Org{ID: types.String{Null: true}, Name: types.String{Value: "twin"}})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `2 organizations named "twin"`)
+}
+
+func TestDataSourceValidateConfig(t *testing.T) {
+	d := NewDataSource()
+
+	assert.False(t, assettest.ValidateDataSourceConfig(t, d, Org{ID: types.String{Value: "org"}, Name: types.String{Null: true}}).HasError())
+	assert.False(t, assettest.ValidateDataSourceConfig(t, d, Org{ID: types.String{Null: true}, Name: types.String{Value: "acme"}}).HasError())
+	assert.True(t, assettest.ValidateDataSourceConfig(t, d, Org{ID: types.String{Value: "org"}, Name: types.String{Value: "acme"}}).HasError())
+	assert.True(t, assettest.ValidateDataSourceConfig(t, d, Org{ID: types.String{Null: true}, Name: types.String{Null: true}}).HasError())
+}
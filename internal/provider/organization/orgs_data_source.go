@@ -0,0 +1,163 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSourceWithValidateConfig = &OrgsDataSource{}
+
+func NewOrgsDataSource() datasource.DataSource {
+	return &OrgsDataSource{}
+}
+
+type OrgsDataSource struct {
+	client client.CloudClient
+}
+
+func (r OrgsDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Every organization the token can see, sorted by name",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name_regex": {
+				Description: "Only list the organizations whose name matches this regular expression",
+				Type:        types.StringType,
+				Optional:    true,
+			},
+			"ids": {
+				Description: "Id of every listed organization",
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+			"organizations": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"id": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *OrgsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizations"
+}
+
+func (r *OrgsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*util.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *OrgsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var nameRegex types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("name_regex"), &nameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || nameRegex.Null || nameRegex.Unknown {
+		return
+	}
+
+	if _, err := regexp.Compile(nameRegex.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			fmt.Sprintf("Could not compile %q: %s", nameRegex.Value, err.Error()),
+		)
+	}
+}
+
+func (r *OrgsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config OrgsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := regexp.Compile(config.NameRegex.Value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			fmt.Sprintf("Could not compile %q: %s", config.NameRegex.Value, err.Error()),
+		)
+		return
+	}
+
+	orgs, err := r.client.ListOrgs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving organizations",
+			err.Error(),
+		)
+		return
+	}
+	slices.SortStableFunc(orgs, func(a, b cac.OrganizationOutput) bool {
+		return a.Name < b.Name
+	})
+
+	state := OrgsDataSourceModel{
+		ID:            types.String{Value: "organizations"},
+		NameRegex:     config.NameRegex,
+		Ids:           types.List{Elems: []attr.Value{}, ElemType: types.StringType},
+		Organizations: []Org{},
+	}
+	for _, org := range orgs {
+		if !nameRegex.MatchString(org.Name) {
+			continue
+		}
+
+		state.Ids.Elems = append(state.Ids.Elems, types.String{Value: org.Id})
+		state.Organizations = append(state.Organizations, Org{
+			ID:   types.String{Value: org.Id},
+			Name: types.String{Value: org.Name},
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
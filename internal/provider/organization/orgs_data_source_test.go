@@ -0,0 +1,49 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/assettest"
+)
+
+func TestOrgsDataSource(t *testing.T) {
+	c, env := assettest.Setup(t)
+	acme := c.AddOrg("acme")
+
+	d := NewOrgsDataSource()
+	assettest.ConfigureDataSource(t, d, c)
+
+	read := func(nameRegex types.String) OrgsDataSourceModel {
+		t.Helper()
+		resp := assettest.ReadDataSource(t, d, OrgsDataSourceModel{
+			ID:        types.String{Null: true},
+			NameRegex: nameRegex,
+			Ids:       types.List{Null: true, ElemType: types.StringType},
+		})
+		assettest.RequireNoError(t, resp.Diagnostics)
+		var state OrgsDataSourceModel
+		assettest.Get(t, resp.State, &state)
+		return state
+	}
+
+	state := read(types.String{Null: true})
+	assert.Equal(t, []Org{
+		{ID: types.String{Value: acme.Id}, Name: types.String{Value: "acme"}},
+		{ID: types.String{Value: env.Organization.Id}, Name: types.String{Value: "test-org"}},
+	}, state.Organizations)
+	assert.Len(t, state.Ids.Elems, 2)
+
+	state = read(types.String{Value: "^test-"})
+	assert.Len(t, state.Organizations, 1)
+	assert.Equal(t, env.Organization.Id, state.Organizations[0].ID.Value)
+
+	diags := assettest.ValidateDataSourceConfig(t, d, OrgsDataSourceModel{
+		ID:        types.String{Null: true},
+		NameRegex: types.String{Value: "[a-"},
+		Ids:       types.List{Null: true, ElemType: types.StringType},
+	})
+	assert.True(t, diags.HasError())
+}
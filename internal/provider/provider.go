@@ -21,8 +21,13 @@ import (
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/aws/redis"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/aws/secret"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/aws/vpc"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/bundles"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/asset/generic"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/connection"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/environment"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/operation"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/provider/organization"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
 )
 
 var _ provider.Provider = &Provider{}
@@ -44,8 +49,9 @@ func (p *Provider) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics)
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
 			"token": {
-				Type:     types.StringType,
-				Optional: true,
+				Type:      types.StringType,
+				Optional:  true,
+				Sensitive: true,
 			},
 			"host": {
 				Type:     types.StringType,
@@ -55,15 +61,34 @@ func (p *Provider) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics)
 				Type:     types.StringType,
 				Optional: true,
 			},
+			"max_retries": {
+				Description: "Number of times a request failing with a 429 or a transient 5xx is retried before the error is reported",
+				Type:        types.Int64Type,
+				Optional:    true,
+			},
+			"organization_id": {
+				Description: "Organization of the resources that don't set their own, APTIBLE_ORGANIZATION_ID when not set",
+				Type:        types.StringType,
+				Optional:    true,
+			},
+			"environment_id": {
+				Description: "Environment of the resources that don't set their own, APTIBLE_ENVIRONMENT_ID when not set",
+				Type:        types.StringType,
+				Optional:    true,
+			},
 		},
 	}, nil
 }
 
 // providerData schema struct
 type providerData struct {
-	Token    types.String `tfsdk:"token"`
-	AuthHost types.String `tfsdk:"auth_host"`
-	Host     types.String `tfsdk:"host"`
+	Token      types.String `tfsdk:"token"`
+	AuthHost   types.String `tfsdk:"auth_host"`
+	Host       types.String `tfsdk:"host"`
+	MaxRetries types.Int64  `tfsdk:"max_retries"`
+
+	OrganizationId types.String `tfsdk:"organization_id"`
+	EnvironmentId  types.String `tfsdk:"environment_id"`
 }
 
 func extractValueFromTokensJson(config *providerData) string {
@@ -165,10 +190,92 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	c := client.NewClient(true, host, token)
+	retry := client.DefaultRetryConfig()
+	if config.MaxRetries.Unknown {
+		resp.Diagnostics.AddError(
+			"Unable to create Client",
+			"Cannot use unknown value as max_retries",
+		)
+		return
+	}
+
+	if !config.MaxRetries.Null {
+		if config.MaxRetries.Value < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid max_retries",
+				"max_retries cannot be negative",
+			)
+			return
+		}
+		retry.MaxRetries = int(config.MaxRetries.Value)
+	}
+
+	// ids the resources fall back to when they do not set their own
+	if config.OrganizationId.Unknown || config.EnvironmentId.Unknown {
+		resp.Diagnostics.AddError(
+			"Unable to create Client",
+			"Cannot use unknown value as organization_id or environment_id",
+		)
+		return
+	}
+	orgId := config.OrganizationId.Value
+	if config.OrganizationId.Null {
+		orgId = os.Getenv("APTIBLE_ORGANIZATION_ID")
+	}
+	envId := config.EnvironmentId.Value
+	if config.EnvironmentId.Null {
+		envId = os.Getenv("APTIBLE_ENVIRONMENT_ID")
+	}
+
+	c := client.NewClient(
+		client.DebugEnabled(), host, token,
+		client.WithRetryConfig(retry),
+		client.WithSensitiveFields(p.sensitiveAttributes(ctx)...),
+	)
+
+	data := &util.ProviderData{
+		Client:         c,
+		OrganizationId: orgId,
+		EnvironmentId:  envId,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+}
+
+// sensitiveAttributes - names of the attributes marked sensitive in any resource schema, their values
+// are masked wherever they show up in debug logs of api requests and responses
+func (p *Provider) sensitiveAttributes(ctx context.Context) []string {
+	names := []string{}
+	var collect func(attributes map[string]tfsdk.Attribute)
+	collect = func(attributes map[string]tfsdk.Attribute) {
+		for name, attribute := range attributes {
+			if attribute.Sensitive {
+				names = append(names, name)
+			}
+			if attribute.Attributes == nil {
+				continue
+			}
+			nested := map[string]tfsdk.Attribute{}
+			for nestedName, nestedAttribute := range attribute.Attributes.GetAttributes() {
+				if a, ok := nestedAttribute.(tfsdk.Attribute); ok {
+					nested[nestedName] = a
+				}
+			}
+			collect(nested)
+		}
+	}
 
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	for _, newResource := range p.Resources(ctx) {
+		schema, diags := newResource().GetSchema(ctx)
+		if diags.HasError() {
+			continue
+		}
+		collect(schema.Attributes)
+		for _, block := range schema.Blocks {
+			collect(block.Attributes)
+		}
+	}
+	return names
 }
 
 func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
@@ -181,14 +288,28 @@ func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 		secret.NewResource,
 		ecscompute.NewResource,
 		acmwaiter.NewResource,
+		environment.NewResource,
+		connection.NewResource,
+		generic.NewResource,
 	}
 }
 
 func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		organization.NewDataSource,
+		organization.NewOrgsDataSource,
 		environment.NewDataSource,
+		environment.NewEnvsDataSource,
 		vpc.NewDataSource,
+		rds.NewDataSource,
+		redis.NewDataSource,
+		secret.NewDataSource,
+		acm.NewDataSource,
+		ecsweb.NewDataSource,
+		ecscompute.NewDataSource,
+		bundles.NewDataSource,
+		generic.NewDataSource,
+		operation.NewDataSource,
 	}
 }
 
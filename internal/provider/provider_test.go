@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fake"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fakeapi"
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/util"
+)
+
+const testAccToken = "test-token"
+
+// testAccProtoV6ProviderFactories - serve the provider in-process for acceptance tests
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"aptible": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccServer - start a local cloud api seeded with an organization and environment, and point the
+// provider at it through APTIBLE_HOST and APTIBLE_TOKEN
+func testAccServer(t *testing.T) (*fakeapi.Server, cac.EnvironmentOutput) {
+	t.Helper()
+
+	previous := util.DefaultTimeToWait
+	util.DefaultTimeToWait = 10 * time.Millisecond
+	t.Cleanup(func() { util.DefaultTimeToWait = previous })
+
+	backend := fake.NewClient()
+	backend.SettleAfter = 50 * time.Millisecond
+	org := backend.AddOrg("acc-org")
+	env := backend.AddEnvironment(org.Id, "acc-env")
+
+	server := fakeapi.NewServer(backend)
+	server.Token = testAccToken
+	ts := server.Start()
+	t.Cleanup(ts.Close)
+
+	t.Setenv("APTIBLE_HOST", ts.URL)
+	t.Setenv("APTIBLE_TOKEN", testAccToken)
+
+	return server, env
+}
+
+func TestSensitiveAttributes(t *testing.T) {
+	p := New("test")().(*Provider)
+	assert.Contains(t, p.sensitiveAttributes(context.Background()), "secret_string")
+	assert.NotContains(t, p.sensitiveAttributes(context.Background()), "name")
+}
+
+func TestConfigureSharesProviderData(t *testing.T) {
+	t.Setenv("APTIBLE_HOST", "http://localhost")
+	t.Setenv("APTIBLE_TOKEN", testAccToken)
+	t.Setenv("APTIBLE_ORGANIZATION_ID", "org")
+	t.Setenv("APTIBLE_ENVIRONMENT_ID", "env")
+
+	p := New("test")().(*Provider)
+	schema, diags := p.GetSchema(context.Background())
+	assert.False(t, diags.HasError())
+
+	// every attribute left out of the provider block
+	objectType := schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config := tfsdk.Config{Schema: schema, Raw: tftypes.NewValue(objectType, values)}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// data sources get the same client and default ids as resources
+	data, ok := resp.DataSourceData.(*util.ProviderData)
+	if assert.True(t, ok) {
+		assert.NotNil(t, data.Client)
+		assert.Equal(t, "org", data.OrganizationId)
+		assert.Equal(t, "env", data.EnvironmentId)
+	}
+	assert.Same(t, resp.ResourceData, resp.DataSourceData)
+}
@@ -0,0 +1,545 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccAssetImportId - build the "{organization_id},{environment_id},{asset_id}" import id of a resource
+func testAccAssetImportId(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", name)
+		}
+		return fmt.Sprintf(
+			"%s,%s,%s",
+			rs.Primary.Attributes["organization_id"],
+			rs.Primary.Attributes["environment_id"],
+			rs.Primary.ID,
+		), nil
+	}
+}
+
+func testAccCheckAssetStatus(server interface {
+	Asset(id string) (cac.AssetOutput, bool)
+}, name string, status cac.AssetStatus) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		asset, ok := server.Asset(rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("asset %s not found in cloud api", rs.Primary.ID)
+		}
+		if asset.Status != status {
+			return fmt.Errorf("asset %s is %s, expected %s", rs.Primary.ID, asset.Status, status)
+		}
+		return nil
+	}
+}
+
+// testAccCheckAssetsDestroyed - every asset left in the cloud api must have been destroyed
+func testAccCheckAssetsDestroyed(server interface {
+	Asset(id string) (cac.AssetOutput, bool)
+}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "aptible_environment" || rs.Type == "aptible_organization" {
+				continue
+			}
+			if asset, ok := server.Asset(rs.Primary.ID); ok && asset.Status != cac.ASSETSTATUS_DESTROYED {
+				return fmt.Errorf("asset %s is still %s", rs.Primary.ID, asset.Status)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccAwsVpc(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(name string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_vpc" "network" {
+  organization_id = %q
+  environment_id  = %q
+  name            = %q
+}
+`, env.Organization.Id, env.Id, name)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("network"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_aws_vpc.network", "name", "network"),
+					resource.TestCheckResourceAttr("aptible_aws_vpc.network", "status", "DEPLOYED"),
+					testAccCheckAssetStatus(server.Backend, "aptible_aws_vpc.network", cac.ASSETSTATUS_DEPLOYED),
+				),
+			},
+			{
+				Config: config("network-renamed"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_vpc.network", "name", "network-renamed"),
+			},
+			{
+				ResourceName:      "aptible_aws_vpc.network",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_vpc.network"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccProviderDefaultIds(t *testing.T) {
+	server, env := testAccServer(t)
+	t.Setenv("APTIBLE_ORGANIZATION_ID", env.Organization.Id)
+	t.Setenv("APTIBLE_ENVIRONMENT_ID", env.Id)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "aptible_aws_vpc" "network" {
+  name = "network"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_aws_vpc.network", "organization_id", env.Organization.Id),
+					resource.TestCheckResourceAttr("aptible_aws_vpc.network", "environment_id", env.Id),
+					testAccCheckAssetStatus(server.Backend, "aptible_aws_vpc.network", cac.ASSETSTATUS_DEPLOYED),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAwsAcm(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(method string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_acm" "cert" {
+  organization_id   = %q
+  environment_id    = %q
+  fqdn              = "www.example.com"
+  validation_method = %q
+}
+
+resource "aptible_aws_acm_waiter" "waiter" {
+  organization_id  = %q
+  environment_id   = %q
+  certificate_arn  = aptible_aws_acm.cert.arn
+  validation_fqdns = [for record in aptible_aws_acm.cert.domain_validation_records : record.resource_record_name]
+}
+`, env.Organization.Id, env.Id, method, env.Organization.Id, env.Id)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("DNS"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aptible_aws_acm.cert", "arn"),
+					resource.TestCheckResourceAttr("aptible_aws_acm.cert", "domain_validation_records.#", "1"),
+					resource.TestCheckResourceAttr("aptible_aws_acm.cert", "domain_validation_records.0.resource_record_type", "CNAME"),
+					resource.TestCheckResourceAttrPair("aptible_aws_acm_waiter.waiter", "certificate_arn", "aptible_aws_acm.cert", "arn"),
+					resource.TestCheckResourceAttr("aptible_aws_acm_waiter.waiter", "validation_fqdns.0", "_validation.www.example.com"),
+				),
+			},
+			{
+				Config: config("EMAIL"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_acm.cert", "validation_method", "EMAIL"),
+			},
+			{
+				ResourceName:      "aptible_aws_acm.cert",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_acm.cert"),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "aptible_aws_acm_waiter.waiter",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_acm_waiter.waiter"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsRds(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(version string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_rds" "db" {
+  organization_id = %q
+  environment_id  = %q
+  vpc_name        = "network"
+  name            = "db"
+  engine          = "postgres"
+  engine_version  = %q
+
+  timeouts {
+    create = "10m"
+    update = "10m"
+  }
+}
+`, env.Organization.Id, env.Id, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("14"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aptible_aws_rds.db", "uri_secret_arn"),
+					resource.TestCheckResourceAttrSet("aptible_aws_rds.db", "secrets_kms_key_arn"),
+					resource.TestCheckResourceAttrSet("aptible_aws_rds.db", "db_identifier"),
+					resource.TestCheckResourceAttr("aptible_aws_rds.db", "timeouts.create", "10m"),
+				),
+			},
+			{
+				Config: config("15"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_rds.db", "engine_version", "15"),
+			},
+			{
+				ResourceName:      "aptible_aws_rds.db",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_rds.db"),
+				ImportStateVerify: true,
+				// timeouts only live in configuration
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccAwsRedis(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(window string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_redis" "cache" {
+  organization_id    = %q
+  environment_id     = %q
+  vpc_name           = "network"
+  name               = "cache"
+  description        = "a cache"
+  snapshot_window    = %q
+  maintenance_window = "sun:05:00-sun:06:00"
+}
+`, env.Organization.Id, env.Id, window)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("04:00-05:00"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aptible_aws_redis.cache", "elasticache_arn"),
+					resource.TestCheckResourceAttrSet("aptible_aws_redis.cache", "uri_secret_arn"),
+				),
+			},
+			{
+				Config: config("02:00-03:00"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_redis.cache", "snapshot_window", "02:00-03:00"),
+			},
+			{
+				ResourceName:      "aptible_aws_redis.cache",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_redis.cache"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsSecret(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(value string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_secret" "secret" {
+  organization_id = %q
+  environment_id  = %q
+  name            = "api-key"
+  secret_string   = %q
+}
+`, env.Organization.Id, env.Id, value)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("hunter2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aptible_aws_secret.secret", "arn"),
+					resource.TestCheckResourceAttrSet("aptible_aws_secret.secret", "kms_arn"),
+				),
+			},
+			{
+				Config: config("correct-horse-battery-staple"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_secret.secret", "secret_string", "correct-horse-battery-staple"),
+			},
+			{
+				ResourceName:      "aptible_aws_secret.secret",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_secret.secret"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsEcsWeb(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(image string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_ecs_web" "web" {
+  organization_id   = %q
+  environment_id    = %q
+  vpc_name          = "network"
+  name              = "web"
+  is_public         = true
+  lb_cert_arn       = "arn:aws:acm:us-east-1:000000000000:certificate/abc"
+  lb_cert_domain    = "www.example.com"
+  container_name    = "nginx"
+  container_image   = %q
+  container_port    = 80
+  container_command = ["nginx", "-g", "daemon off;"]
+
+  environment_secrets = {
+    DATABASE_URL = {
+      secret_arn      = "arn:aws:secretsmanager:us-east-1:000000000000:secret/db"
+      secret_json_key = "uri"
+    }
+  }
+}
+`, env.Organization.Id, env.Id, image)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("nginx:alpine"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aptible_aws_ecs_web.web", "load_balancer_url"),
+					resource.TestCheckResourceAttr("aptible_aws_ecs_web.web", "lb_cert_domain", "www.example.com"),
+					resource.TestCheckResourceAttr("aptible_aws_ecs_web.web", "container_port", "80"),
+				),
+			},
+			{
+				Config: config("nginx:1.23-alpine"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_ecs_web.web", "container_image", "nginx:1.23-alpine"),
+			},
+			{
+				ResourceName:      "aptible_aws_ecs_web.web",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_ecs_web.web"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAwsEcsCompute(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(command string) string {
+		return fmt.Sprintf(`
+resource "aptible_aws_ecs_compute" "worker" {
+  organization_id     = %q
+  environment_id      = %q
+  vpc_name            = "network"
+  name                = "worker"
+  container_name      = "worker"
+  container_image     = "quay.io/aptible/worker:latest"
+  container_port      = 8080
+  container_command   = [%q]
+  environment_secrets = {}
+}
+`, env.Organization.Id, env.Id, command)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("bin/worker"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_ecs_compute.worker", "status", "DEPLOYED"),
+			},
+			{
+				Config: config("bin/worker-v2"),
+				Check:  resource.TestCheckResourceAttr("aptible_aws_ecs_compute.worker", "container_command.0", "bin/worker-v2"),
+			},
+			{
+				ResourceName:      "aptible_aws_ecs_compute.worker",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_aws_ecs_compute.worker"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccEnvironment(t *testing.T) {
+	server, env := testAccServer(t)
+	server.Backend.ProvisionPolls = 2
+	config := func(name, description string) string {
+		return fmt.Sprintf(`
+resource "aptible_environment" "staging" {
+  org_id      = %q
+  name        = %q
+  description = %q
+}
+`, env.Organization.Id, name, description)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("staging", "pre-production"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_environment.staging", "name", "staging"),
+					resource.TestCheckResourceAttrSet("aptible_environment.staging", "aws_account_id"),
+				),
+			},
+			{
+				Config: config("staging-renamed", "pre-production"),
+				Check:  resource.TestCheckResourceAttr("aptible_environment.staging", "name", "staging-renamed"),
+			},
+			{
+				ResourceName: "aptible_environment.staging",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["aptible_environment.staging"]
+					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["org_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccConnection(t *testing.T) {
+	server, env := testAccServer(t)
+	config := fmt.Sprintf(`
+resource "aptible_aws_rds" "db" {
+  organization_id = %[1]q
+  environment_id  = %[2]q
+  vpc_name        = "network"
+  name            = "db"
+  engine          = "postgres"
+  engine_version  = "14"
+}
+
+resource "aptible_aws_ecs_compute" "worker" {
+  organization_id     = %[1]q
+  environment_id      = %[2]q
+  vpc_name            = "network"
+  name                = "worker"
+  container_name      = "worker"
+  container_image     = "quay.io/aptible/worker:latest"
+  container_port      = 8080
+  container_command   = ["bin/worker"]
+  environment_secrets = {}
+}
+
+resource "aptible_connection" "worker_db" {
+  organization_id   = %[1]q
+  environment_id    = %[2]q
+  asset_id          = aptible_aws_ecs_compute.worker.id
+  outgoing_asset_id = aptible_aws_rds.db.id
+}
+`, env.Organization.Id, env.Id)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_connection.worker_db", "status", "ACTIVE"),
+					resource.TestCheckResourceAttrPair("aptible_connection.worker_db", "outgoing_asset_id", "aptible_aws_rds.db", "id"),
+				),
+			},
+			{
+				ResourceName: "aptible_connection.worker_db",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["aptible_connection.worker_db"]
+					return fmt.Sprintf(
+						"%s,%s,%s,%s",
+						rs.Primary.Attributes["organization_id"],
+						rs.Primary.Attributes["environment_id"],
+						rs.Primary.Attributes["asset_id"],
+						rs.Primary.ID,
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAsset(t *testing.T) {
+	server, env := testAccServer(t)
+	config := func(name string) string {
+		return fmt.Sprintf(`
+resource "aptible_asset" "network" {
+  organization_id = %q
+  environment_id  = %q
+  asset_platform  = "aws"
+  asset_type      = "vpc"
+
+  parameters = jsonencode({
+    name = %q
+  })
+}
+`, env.Organization.Id, env.Id, name)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(server.Backend),
+		Steps: []resource.TestStep{
+			{
+				Config: config("network"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aptible_asset.network", "asset_version", "latest"),
+					resource.TestCheckResourceAttr("aptible_asset.network", "status", "DEPLOYED"),
+					testAccCheckAssetStatus(server.Backend, "aptible_asset.network", cac.ASSETSTATUS_DEPLOYED),
+				),
+			},
+			{
+				Config: config("network-renamed"),
+				Check:  resource.TestCheckResourceAttr("aptible_asset.network", "parameters", `{"name":"network-renamed"}`),
+			},
+			{
+				ResourceName:      "aptible_asset.network",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAssetImportId("aptible_asset.network"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
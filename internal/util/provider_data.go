@@ -0,0 +1,76 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
+)
+
+// ProviderData - what the provider hands every resource and data source when it is configured
+type ProviderData struct {
+	Client client.CloudClient
+	// OrganizationId - organization_id of the provider block, empty when not set
+	OrganizationId string
+	// EnvironmentId - environment_id of the provider block, empty when not set
+	EnvironmentId string
+}
+
+// providerDefault - the value of a provider attribute resources fall back to
+func (d *ProviderData) providerDefault(name string) string {
+	if d == nil {
+		return ""
+	}
+	switch name {
+	case "organization_id":
+		return d.OrganizationId
+	case "environment_id":
+		return d.EnvironmentId
+	}
+	return ""
+}
+
+// SetPlanDefaults - fill resource attributes left out of the configuration with the provider attribute
+// they map to, e.g. {"org_id": "organization_id"}. Attributes already planned, e.g. kept from state, are
+// left alone so a change of the provider's defaults never moves an existing resource.
+func SetPlanDefaults(ctx context.Context, data *ProviderData, defaults map[string]string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to fill in when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for attribute, providerAttribute := range defaults {
+		var configured, planned types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &configured)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(attribute), &planned)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !configured.Null || !planned.Unknown {
+			continue
+		}
+
+		value := data.providerDefault(providerAttribute)
+		if value == "" {
+			// the provider is not configured yet during validation, it will be again when planning
+			if data == nil {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				fmt.Sprintf("Missing %s", attribute),
+				fmt.Sprintf(
+					"%s must be set on the resource, or %s on the provider block or with the APTIBLE_%s environment variable",
+					attribute, providerAttribute, strings.ToUpper(providerAttribute),
+				),
+			)
+			continue
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), types.String{Value: value})...)
+	}
+}
@@ -3,11 +3,14 @@ package util
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	cac "github.com/aptible/cloud-api-clients/clients/go"
 	"github.com/aptible/terraform-provider-aptible-iaas/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -15,7 +18,8 @@ import (
 // DefaultTimeToWait - typical time.sleep to wait before waiting to try agian
 var DefaultTimeToWait = 10 * time.Second
 
-// TimeToFail - maximum time to wait before failing a given operation completion
+// TimeToFail - maximum time to wait before failing a given operation completion, when the caller
+// does not pass a timeout
 var TimeToFail = 1 * time.Hour
 
 // AssetStatusesThatIndicateCompletion - these statuses indicate the asset requested is now in a somewhat
@@ -28,19 +32,276 @@ var AssetStatusesThatIndicateCompletion = []cac.AssetStatus{
 // ErrorTimeOutOnAssetStatus - error that's returned when asset waiter times out
 var ErrorTimeOutOnAssetStatus = fmt.Errorf("timed out when waiting for asset status")
 
-func WaitForAssetStatusInOperationCompleteState(client client.CloudClient, ctx context.Context, orgId, envId, id string) (*cac.AssetOutput, error) {
+// ErrorTimeOutOnEnvironment - error that's returned when the environment waiter times out
+var ErrorTimeOutOnEnvironment = fmt.Errorf("timed out when waiting for environment to be provisioned")
+
+// ErrorTimeOutOnConnection - error that's returned when the connection waiter times out
+var ErrorTimeOutOnConnection = fmt.Errorf("timed out when waiting for connection to be active")
+
+// ErrorConnectionFailed - wrapped by the error returned when a connection goes FAILED
+var ErrorConnectionFailed = fmt.Errorf("connection failed")
+
+// OperationStatusesThatIndicateFailure - terminal statuses of an operation that did not apply its change
+var OperationStatusesThatIndicateFailure = []cac.OperationStatus{
+	cac.OPERATIONSTATUS_FAILED,
+	cac.OPERATIONSTATUS_CANCELED,
+}
+
+// ErrorOperationFailed - wrapped by the error returned when the operation waited on fails or is cancelled,
+// or when the asset goes FAILED
+var ErrorOperationFailed = fmt.Errorf("operation failed")
+
+// LogExcerptLines - number of trailing log lines of a failed operation quoted in errors
+var LogExcerptLines = 20
+
+// WaitForAssetOperation - wait for the operation started by a create, update or delete to reach a terminal
+// state, then return the asset as the operation left it. Unlike waiting on the asset status this can't
+// be fooled by the status left over from a previous operation. operationId is the one returned by the
+// api. When it is nil the operation is looked up among the ones of the asset created at or after
+// requestedAt, when the change was sent, polling until the backend lists it.
+func WaitForAssetOperation(client client.CloudClient, ctx context.Context, orgId, envId, id string, operationId *string, requestedAt time.Time, timeout time.Duration) (*cac.AssetOutput, error) {
+	if timeout <= 0 {
+		timeout = TimeToFail
+	}
+	deadline := time.Now().Add(timeout)
+
+	if operationId == nil {
+		op, err := waitForOperationSince(client, ctx, orgId, id, requestedAt, deadline)
+		if err != nil {
+			return nil, err
+		}
+		operationId = &op.Id
+	}
+
+	tflog.Info(
+		ctx, "waiting for operation",
+		map[string]interface{}{
+			"id":          id,
+			"envId":       envId,
+			"operationId": *operationId,
+			"timeout":     timeout.String(),
+		},
+	)
+
+	parent := ctx
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	for {
+		if deadline.Before(time.Now()) || (ctx.Err() != nil && parent.Err() == nil) {
+			return nil, fmt.Errorf("%w %s, operation %s did not complete after %s", ErrorTimeOutOnAssetStatus, id, *operationId, timeout)
+		}
+
+		op, err := client.DescribeOperation(ctx, orgId, *operationId)
+		if err != nil {
+			if ctx.Err() != nil && parent.Err() == nil {
+				continue
+			}
+			return nil, err
+		}
+
+		status := op.GetStatus()
+		if isFailed(status) {
+			return nil, assetFailedError(client, parent, orgId, id, op)
+		}
+		if status == cac.OPERATIONSTATUS_COMPLETE {
+			tflog.Info(ctx, "Completed waiting for operation", map[string]interface{}{"operationId": op.Id, "type": op.GetOperationType()})
+			break
+		}
+
+		if err := sleep(ctx, DefaultTimeToWait); err != nil && parent.Err() != nil {
+			return nil, err
+		}
+		tflog.Info(ctx,
+			"Still waiting for operation on provided asset",
+			map[string]interface{}{
+				"id":          id,
+				"operationId": *operationId,
+				"status":      status,
+			})
+	}
+
+	asset, err := client.DescribeAsset(parent, orgId, envId, id)
+	if err != nil {
+		return nil, err
+	}
+	if asset.Status == cac.ASSETSTATUS_FAILED {
+		return nil, assetFailedError(client, parent, orgId, id, nil)
+	}
+	return asset, nil
+}
+
+// waitForOperationSince - poll the operations of the asset until one created at or after requestedAt shows
+// up. The latest one before that may be the previous, already complete, operation of the asset
+func waitForOperationSince(c client.CloudClient, ctx context.Context, orgId, id string, requestedAt, deadline time.Time) (*client.Operation, error) {
+	parent := ctx
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	for {
+		op, err := latestOperation(c, ctx, orgId, id, requestedAt)
+		if err != nil && (ctx.Err() == nil || parent.Err() != nil) {
+			return nil, err
+		}
+		if op != nil {
+			return op, nil
+		}
+
+		if err := sleep(ctx, DefaultTimeToWait); err != nil {
+			if parent.Err() != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf(
+				"%w %s, no operation started at or after %s showed up",
+				ErrorTimeOutOnAssetStatus, id, requestedAt.UTC().Format(time.RFC3339),
+			)
+		}
+		tflog.Info(ctx, "Still waiting for the operation on provided asset to show up", map[string]interface{}{"id": id})
+	}
+}
+
+// latestOperation - the operation most recently started on the asset at or after since, preferring one still
+// running. The api doesn't promise an order for the list, so operations are compared by when they started.
+// Operations without a time can't be told apart from older ones and are left out
+func latestOperation(c client.CloudClient, ctx context.Context, orgId, id string, since time.Time) (*client.Operation, error) {
+	ops, err := c.ListOperationsByAsset(ctx, orgId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *client.Operation
+	for idx := range ops {
+		op := &ops[idx]
+		startedAt := operationStartedAt(op)
+		if startedAt == nil || startedAt.Before(since) {
+			continue
+		}
+		if latest == nil || isNewerOperation(op, latest) {
+			latest = op
+		}
+	}
+	return latest, nil
+}
+
+// isNewerOperation - op is still running while latest isn't, or they both are or aren't and op started
+// later. Operations the api sent no timestamps for count as the oldest, when neither has one the later
+// listed wins
+func isNewerOperation(op, latest *client.Operation) bool {
+	running, latestRunning := !isTerminal(op.GetStatus()), !isTerminal(latest.GetStatus())
+	if running != latestRunning {
+		return running
+	}
+
+	startedAt, latestStartedAt := operationStartedAt(op), operationStartedAt(latest)
+	if latestStartedAt == nil {
+		return true
+	}
+	if startedAt == nil {
+		return false
+	}
+	return !startedAt.Before(*latestStartedAt)
+}
+
+// operationStartedAt - when the operation was created, or last updated when the api didn't say
+func operationStartedAt(op *client.Operation) *time.Time {
+	if op.CreatedAt != nil {
+		return op.CreatedAt
+	}
+	return op.UpdatedAt
+}
+
+// assetFailedError - explain why the asset failed, quoting the failed operation from the latest
+// operations of the asset. known is the operation waited on, if any, and is used when it can't be
+// found in the list
+func assetFailedError(c client.CloudClient, ctx context.Context, orgId, id string, known *client.Operation) error {
+	var failed *client.Operation
+	ops, err := c.ListOperationsByAsset(ctx, orgId, id)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list operations of failed asset", map[string]interface{}{"id": id, "error": err.Error()})
+	}
+	for idx := range ops {
+		op := &ops[idx]
+		if known != nil && op.Id == known.Id {
+			failed = op
+			break
+		}
+		if known == nil && isFailed(op.GetStatus()) {
+			failed = op
+		}
+	}
+	if failed == nil {
+		failed = known
+	}
+
+	if failed == nil {
+		return fmt.Errorf("%w: asset %s is FAILED, no failed operation was found for it", ErrorOperationFailed, id)
+	}
+	return fmt.Errorf("%w: asset %s is FAILED\n%s", ErrorOperationFailed, id, DescribeOperation(*failed))
+}
+
+// DescribeOperation - a human readable summary of an operation: its type, status, when it ran, why it
+// failed and the tail of its logs
+func DescribeOperation(op client.Operation) string {
+	lines := []string{
+		fmt.Sprintf("%s operation %s is %s", op.GetOperationType(), op.Id, op.GetStatus()),
+	}
+	if op.CreatedAt != nil {
+		lines = append(lines, fmt.Sprintf("started: %s", op.CreatedAt.Format(time.RFC3339)))
+	}
+	if op.UpdatedAt != nil {
+		lines = append(lines, fmt.Sprintf("last updated: %s", op.UpdatedAt.Format(time.RFC3339)))
+	}
+	if op.Error != "" {
+		lines = append(lines, fmt.Sprintf("error: %s", strings.TrimSpace(op.Error)))
+	}
+	if op.Logs != "" {
+		logs := strings.Split(strings.TrimRight(op.Logs, "\n"), "\n")
+		if len(logs) > LogExcerptLines {
+			logs = logs[len(logs)-LogExcerptLines:]
+		}
+		lines = append(lines, fmt.Sprintf("logs (last %d lines):", len(logs)))
+		lines = append(lines, logs...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isFailed(status cac.OperationStatus) bool {
+	for _, failed := range OperationStatusesThatIndicateFailure {
+		if status == failed {
+			return true
+		}
+	}
+	return false
+}
+
+func isTerminal(status cac.OperationStatus) bool {
+	return status == cac.OPERATIONSTATUS_COMPLETE || isFailed(status)
+}
+
+// WaitForAssetStatusInOperationCompleteState - poll the asset until it settles, giving up after timeout
+// (TimeToFail when zero) or when ctx is cancelled
+func WaitForAssetStatusInOperationCompleteState(client client.CloudClient, ctx context.Context, orgId, envId, id string, timeout time.Duration) (*cac.AssetOutput, error) {
+	if timeout <= 0 {
+		timeout = TimeToFail
+	}
 	tflog.Info(
 		ctx, "waiting for asset status",
 		map[string]interface{}{
-			"id":    id,
-			"envId": envId,
+			"id":      id,
+			"envId":   envId,
+			"timeout": timeout.String(),
 		},
 	)
-	totalTimeRunning := time.Now().Add(TimeToFail)
+	totalTimeRunning := time.Now().Add(timeout)
 	invalidServerResponseRetries := 0
 
+	// stop in-flight requests and sleeps at the deadline rather than overshooting it
+	parent := ctx
+	ctx, cancel := context.WithDeadline(ctx, totalTimeRunning)
+	defer cancel()
+
 	for {
-		if totalTimeRunning.Before(time.Now()) {
+		if totalTimeRunning.Before(time.Now()) || (ctx.Err() != nil && parent.Err() == nil) {
 			tflog.Error(
 				ctx,
 				"Error when waiting for status",
@@ -50,10 +311,13 @@ func WaitForAssetStatusInOperationCompleteState(client client.CloudClient, ctx c
 					"orgId":            orgId,
 					"totalTimeRunning": totalTimeRunning,
 				})
-			return nil, ErrorTimeOutOnAssetStatus
+			return nil, fmt.Errorf("%w %s after %s", ErrorTimeOutOnAssetStatus, id, timeout)
 		}
 		asset, err := client.DescribeAsset(ctx, orgId, envId, id)
 		if err != nil {
+			if ctx.Err() != nil && parent.Err() == nil {
+				continue
+			}
 			return nil, err
 		}
 
@@ -64,12 +328,14 @@ func WaitForAssetStatusInOperationCompleteState(client client.CloudClient, ctx c
 		} else if asset == nil {
 			invalidServerResponseRetries += 1
 			tflog.Warn(ctx, "Unable to get asset body, but no error present. You may need to enable TF_LOG=debug to see more details. retrying after a short pause.")
-			time.Sleep(DefaultTimeToWait)
+			if err := sleep(ctx, DefaultTimeToWait); err != nil && parent.Err() != nil {
+				return nil, err
+			}
 			continue
 		}
 
 		if asset.Status == cac.ASSETSTATUS_FAILED {
-			return nil, fmt.Errorf("Asset status FAILED %s", id)
+			return nil, assetFailedError(client, ctx, orgId, id, nil)
 		}
 
 		for _, completedOperationStatus := range AssetStatusesThatIndicateCompletion {
@@ -80,7 +346,9 @@ func WaitForAssetStatusInOperationCompleteState(client client.CloudClient, ctx c
 			}
 		}
 		// actively wait for status
-		time.Sleep(DefaultTimeToWait)
+		if err := sleep(ctx, DefaultTimeToWait); err != nil && parent.Err() != nil {
+			return nil, err
+		}
 		tflog.Info(ctx,
 			"Still waiting for status on provided asset",
 			map[string]interface{}{
@@ -92,6 +360,99 @@ func WaitForAssetStatusInOperationCompleteState(client client.CloudClient, ctx c
 	}
 }
 
+// WaitForEnvironmentProvisioned - poll a newly created environment until its aws account is provisioned,
+// giving up after timeout (TimeToFail when zero) or when ctx is cancelled
+func WaitForEnvironmentProvisioned(c client.CloudClient, ctx context.Context, orgId, envId string, timeout time.Duration) (*cac.EnvironmentOutput, error) {
+	if timeout <= 0 {
+		timeout = TimeToFail
+	}
+	tflog.Info(
+		ctx, "waiting for environment to be provisioned",
+		map[string]interface{}{
+			"envId":   envId,
+			"timeout": timeout.String(),
+		},
+	)
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		env, err := c.DescribeEnvironment(ctx, orgId, envId)
+		if err == nil && env != nil && env.AwsAccountId != nil && *env.AwsAccountId != "" {
+			return env, nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+
+		if err := sleep(ctx, DefaultTimeToWait); err != nil {
+			if parent.Err() != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w %s after %s", ErrorTimeOutOnEnvironment, envId, timeout)
+		}
+		tflog.Info(ctx, "Still waiting for environment to be provisioned", map[string]interface{}{"envId": envId})
+	}
+}
+
+// WaitForConnectionActive - poll a newly created connection until it's ACTIVE, giving up when it goes FAILED,
+// after timeout (TimeToFail when zero) or when ctx is cancelled
+func WaitForConnectionActive(c client.CloudClient, ctx context.Context, orgId, envId, assetId, connectionId string, timeout time.Duration) (*cac.ConnectionOutput, error) {
+	if timeout <= 0 {
+		timeout = TimeToFail
+	}
+	tflog.Info(
+		ctx, "waiting for connection to be active",
+		map[string]interface{}{
+			"id":      connectionId,
+			"assetId": assetId,
+			"timeout": timeout.String(),
+		},
+	)
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		conn, err := c.GetConnection(ctx, orgId, envId, assetId, connectionId)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if err == nil && conn != nil {
+			switch conn.Status {
+			case cac.CONNECTIONSTATUS_ACTIVE:
+				return conn, nil
+			case cac.CONNECTIONSTATUS_FAILED, cac.CONNECTIONSTATUS_DELETED:
+				return nil, fmt.Errorf("%w: connection %s is %s", ErrorConnectionFailed, connectionId, conn.Status)
+			}
+		}
+
+		if err := sleep(ctx, DefaultTimeToWait); err != nil {
+			if parent.Err() != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w %s after %s", ErrorTimeOutOnConnection, connectionId, timeout)
+		}
+		tflog.Info(ctx, "Still waiting for connection to be active", map[string]interface{}{"id": connectionId})
+	}
+}
+
+// sleep - wait for d, returning early with the context's error when terraform cancels the run
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func SafeString(obj interface{}) string {
 	switch val := obj.(type) {
 	case string:
@@ -129,3 +490,112 @@ func BoolVal(input interface{}) types.Bool {
 	}
 	return val
 }
+
+// SafeInt64 - a whole json number, decoded as float64, or 0
+func SafeInt64(obj interface{}) int64 {
+	switch val := obj.(type) {
+	case float64:
+		return int64(val)
+	case int64:
+		return val
+	case int:
+		return int64(val)
+	default:
+		return 0
+	}
+}
+
+func Int64Val(input interface{}) types.Int64 {
+	val := types.Int64{}
+	if input == nil {
+		val.Null = true
+	} else {
+		val.Value = SafeInt64(input)
+	}
+	return val
+}
+
+func SafeFloat64(obj interface{}) float64 {
+	switch val := obj.(type) {
+	case float64:
+		return val
+	case int64:
+		return float64(val)
+	case int:
+		return float64(val)
+	default:
+		return 0
+	}
+}
+
+func NumberVal(input interface{}) types.Number {
+	val := types.Number{}
+	if input == nil {
+		val.Null = true
+	} else {
+		val.Value = big.NewFloat(SafeFloat64(input))
+	}
+	return val
+}
+
+// StringListVal - a json list of strings as a list, empty when there is none
+func StringListVal(input interface{}) types.List {
+	elems := []attr.Value{}
+	if list, ok := input.([]interface{}); ok {
+		for _, elem := range list {
+			elems = append(elems, types.String{Value: SafeString(elem)})
+		}
+	}
+	return types.List{Elems: elems, ElemType: types.StringType}
+}
+
+// OptionalStringListVal - a json list of strings as a list, null when there is none so an optional
+// attribute left unset doesn't show as a diff
+func OptionalStringListVal(input interface{}) types.List {
+	val := StringListVal(input)
+	if len(val.Elems) == 0 {
+		return types.List{Null: true, ElemType: types.StringType}
+	}
+	return val
+}
+
+// StringsFromList - the values of a list of strings, empty while it is null or unknown
+func StringsFromList(ctx context.Context, list types.List) []string {
+	values := []string{}
+	if list.IsNull() || list.IsUnknown() {
+		return values
+	}
+	_ = list.ElementsAs(ctx, &values, false)
+	return values
+}
+
+// Float64FromNumber - the value of a number as the api expects it, a *big.Float marshals to a json string
+func Float64FromNumber(n types.Number) float64 {
+	if n.Value == nil {
+		return 0
+	}
+	val, _ := n.Value.Float64()
+	return val
+}
+
+// StringsVal - a json list of strings as the []types.String of a model, nil when there is none
+func StringsVal(input interface{}) []types.String {
+	list, ok := input.([]interface{})
+	if !ok {
+		return nil
+	}
+	values := []types.String{}
+	for _, elem := range list {
+		values = append(values, types.String{Value: SafeString(elem)})
+	}
+	return values
+}
+
+// StringsFromValues - the values of the []types.String of a model
+func StringsFromValues(values []types.String) []string {
+	out := []string{}
+	for _, value := range values {
+		out = append(out, value.Value)
+	}
+	return out
+}
@@ -0,0 +1,282 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cac "github.com/aptible/cloud-api-clients/clients/go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aptible/terraform-provider-aptible-iaas/internal/client/fake"
+)
+
+func TestWaitForAssetStatusReturnsOnCancel(t *testing.T) {
+	previous := DefaultTimeToWait
+	DefaultTimeToWait = time.Hour
+	t.Cleanup(func() { DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	org := c.AddOrg("org")
+	env := c.AddEnvironment(org.Id, "env")
+	asset, err := c.CreateAsset(context.Background(), org.Id, env.Id, cac.AssetInput{Asset: "aws__vpc__latest"})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = WaitForAssetStatusInOperationCompleteState(c, ctx, org.Id, env.Id, asset.Id, 0)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWaitForAssetStatusCompletes(t *testing.T) {
+	previous := DefaultTimeToWait
+	DefaultTimeToWait = time.Millisecond
+	t.Cleanup(func() { DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	org := c.AddOrg("org")
+	env := c.AddEnvironment(org.Id, "env")
+	asset, err := c.CreateAsset(context.Background(), org.Id, env.Id, cac.AssetInput{Asset: "aws__vpc__latest"})
+	assert.Nil(t, err)
+
+	deployed, err := WaitForAssetStatusInOperationCompleteState(c, context.Background(), org.Id, env.Id, asset.Id, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DEPLOYED, deployed.Status)
+}
+
+func TestWaitForAssetStatusTimesOut(t *testing.T) {
+	previous := DefaultTimeToWait
+	DefaultTimeToWait = time.Hour
+	t.Cleanup(func() { DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	org := c.AddOrg("org")
+	env := c.AddEnvironment(org.Id, "env")
+	asset, err := c.CreateAsset(context.Background(), org.Id, env.Id, cac.AssetInput{Asset: "aws__vpc__latest"})
+	assert.Nil(t, err)
+
+	start := time.Now()
+	_, err = WaitForAssetStatusInOperationCompleteState(c, context.Background(), org.Id, env.Id, asset.Id, 10*time.Millisecond)
+	assert.True(t, errors.Is(err, ErrorTimeOutOnAssetStatus))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func setupDeployedAsset(t *testing.T) (*fake.Client, *cac.AssetOutput) {
+	t.Helper()
+	previous := DefaultTimeToWait
+	DefaultTimeToWait = time.Millisecond
+	t.Cleanup(func() { DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	org := c.AddOrg("org")
+	env := c.AddEnvironment(org.Id, "env")
+	asset, err := c.CreateAsset(context.Background(), org.Id, env.Id, cac.AssetInput{Asset: "aws__vpc__latest"})
+	assert.Nil(t, err)
+
+	deployed, err := WaitForAssetOperation(c, context.Background(), org.Id, env.Id, asset.Id, asset.OperationId, time.Time{}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DEPLOYED, deployed.Status)
+	return c, deployed
+}
+
+func TestWaitForAssetOperationIgnoresStaleStatus(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	c.StaleStatus = true
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	updated, err := c.UpdateAsset(context.Background(), asset.Id, envId, orgId, cac.AssetInput{
+		Asset:           asset.Asset,
+		AssetParameters: map[string]interface{}{"name": "renamed"},
+	})
+	assert.Nil(t, err)
+	// the backend has not picked the operation up yet, the asset still looks deployed
+	assert.Equal(t, cac.ASSETSTATUS_DEPLOYED, updated.Status)
+
+	_, err = WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, updated.OperationId, time.Time{}, 0)
+	assert.Nil(t, err)
+
+	op, err := c.DescribeOperation(context.Background(), orgId, *updated.OperationId)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.OPERATIONSTATUS_COMPLETE, op.GetStatus())
+}
+
+func TestWaitForAssetOperationLooksUpOperation(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	requestedAt := time.Now()
+	destroying, err := c.DestroyAsset(context.Background(), orgId, envId, asset.Id)
+	assert.Nil(t, err)
+
+	destroyed, err := WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, nil, requestedAt, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DESTROYED, destroyed.Status)
+
+	op, err := c.DescribeOperation(context.Background(), orgId, *destroying.OperationId)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.OPERATIONSTATUS_COMPLETE, op.GetStatus())
+}
+
+func TestWaitForAssetOperationWaitsForNewOperation(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	// the backend lists the update a few polls after accepting it, until then the completed create is the
+	// latest operation of the asset
+	c.UnlistedOperationPolls = 3
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	requestedAt := time.Now()
+	updated, err := c.UpdateAsset(context.Background(), asset.Id, envId, orgId, cac.AssetInput{
+		Asset:           asset.Asset,
+		AssetParameters: map[string]interface{}{"name": "renamed"},
+	})
+	assert.Nil(t, err)
+
+	deployed, err := WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, nil, requestedAt, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.ASSETSTATUS_DEPLOYED, deployed.Status)
+
+	op, err := c.DescribeOperation(context.Background(), orgId, *updated.OperationId)
+	assert.Nil(t, err)
+	assert.Equal(t, cac.OPERATIONSTATUS_COMPLETE, op.GetStatus())
+}
+
+func TestWaitForAssetOperationTimesOutWithoutNewOperation(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	// only the create, already complete, is listed
+	_, err := WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, nil, time.Now(), 20*time.Millisecond)
+	assert.ErrorIs(t, err, ErrorTimeOutOnAssetStatus)
+	assert.ErrorContains(t, err, "no operation started at or after")
+}
+
+func TestLatestOperationIgnoresListOrder(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	c.NewestOperationsFirst = true
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	updated, err := c.UpdateAsset(context.Background(), asset.Id, envId, orgId, cac.AssetInput{Asset: asset.Asset})
+	assert.Nil(t, err)
+	_, err = WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, updated.OperationId, time.Time{}, 0)
+	assert.Nil(t, err)
+
+	// both the create and the update are done, the update is listed first but started last
+	ops, err := c.ListOperationsByAsset(context.Background(), orgId, asset.Id)
+	assert.Nil(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, *updated.OperationId, ops[0].Id)
+
+	latest, err := latestOperation(c, context.Background(), orgId, asset.Id, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, *updated.OperationId, latest.Id)
+
+	// the same holds once the create is listed last
+	c.NewestOperationsFirst = false
+	latest, err = latestOperation(c, context.Background(), orgId, asset.Id, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, *updated.OperationId, latest.Id)
+}
+
+func TestLatestOperationPrefersRunning(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	c.NewestOperationsFirst = true
+	c.PollsToSettle = 100
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	updated, err := c.UpdateAsset(context.Background(), asset.Id, envId, orgId, cac.AssetInput{Asset: asset.Asset})
+	assert.Nil(t, err)
+
+	latest, err := latestOperation(c, context.Background(), orgId, asset.Id, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, *updated.OperationId, latest.Id)
+	assert.Equal(t, cac.OPERATIONSTATUS_IN_PROGRESS, latest.GetStatus())
+}
+
+func TestWaitForAssetOperationReportsFailure(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	c.PollsToSettle = 100
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	updated, err := c.UpdateAsset(context.Background(), asset.Id, envId, orgId, cac.AssetInput{Asset: asset.Asset})
+	assert.Nil(t, err)
+	c.SetAssetStatus(asset.Id, cac.ASSETSTATUS_FAILED)
+
+	_, err = WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, updated.OperationId, time.Time{}, 0)
+	assert.True(t, errors.Is(err, ErrorOperationFailed))
+	assert.Contains(t, err.Error(), "FAILED")
+}
+
+func TestWaitForAssetOperationDescribesFailure(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	c.PollsToSettle = 100
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	updated, err := c.UpdateAsset(context.Background(), asset.Id, envId, orgId, cac.AssetInput{Asset: asset.Asset})
+	assert.Nil(t, err)
+	logs := ""
+	for i := 0; i < 30; i++ {
+		logs += fmt.Sprintf("line %d\n", i)
+	}
+	c.FailAsset(asset.Id, "Error: InvalidParameterCombination", logs)
+
+	_, err = WaitForAssetOperation(c, context.Background(), orgId, envId, asset.Id, updated.OperationId, time.Time{}, 0)
+	assert.True(t, errors.Is(err, ErrorOperationFailed))
+	assert.Contains(t, err.Error(), "UPDATE operation "+*updated.OperationId+" is FAILED")
+	assert.Contains(t, err.Error(), "started: ")
+	assert.Contains(t, err.Error(), "error: Error: InvalidParameterCombination")
+	assert.Contains(t, err.Error(), "logs (last 20 lines):")
+	assert.Contains(t, err.Error(), "line 29")
+	assert.NotContains(t, err.Error(), "line 9\n")
+}
+
+func TestWaitForAssetStatusDescribesFailure(t *testing.T) {
+	c, asset := setupDeployedAsset(t)
+	c.PollsToSettle = 100
+	orgId, envId := asset.Environment.Organization.Id, asset.Environment.Id
+
+	_, err := c.DestroyAsset(context.Background(), orgId, envId, asset.Id)
+	assert.Nil(t, err)
+	c.FailAsset(asset.Id, "dependency violation", "")
+
+	_, err = WaitForAssetStatusInOperationCompleteState(c, context.Background(), orgId, envId, asset.Id, 0)
+	assert.True(t, errors.Is(err, ErrorOperationFailed))
+	assert.Contains(t, err.Error(), "DESTROY operation")
+	assert.Contains(t, err.Error(), "error: dependency violation")
+}
+
+func TestWaitForEnvironmentProvisioned(t *testing.T) {
+	previous := DefaultTimeToWait
+	DefaultTimeToWait = time.Millisecond
+	t.Cleanup(func() { DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	c.ProvisionPolls = 3
+	org := c.AddOrg("org")
+	env, err := c.CreateEnvironment(context.Background(), org.Id, cac.EnvironmentInput{Name: "env"})
+	assert.Nil(t, err)
+	assert.Nil(t, env.AwsAccountId)
+
+	provisioned, err := WaitForEnvironmentProvisioned(c, context.Background(), org.Id, env.Id, 0)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, provisioned.GetAwsAccountId())
+}
+
+func TestWaitForEnvironmentProvisionedTimesOut(t *testing.T) {
+	previous := DefaultTimeToWait
+	DefaultTimeToWait = time.Hour
+	t.Cleanup(func() { DefaultTimeToWait = previous })
+
+	c := fake.NewClient()
+	c.ProvisionPolls = 3
+	org := c.AddOrg("org")
+	env, err := c.CreateEnvironment(context.Background(), org.Id, cac.EnvironmentInput{Name: "env"})
+	assert.Nil(t, err)
+
+	_, err = WaitForEnvironmentProvisioned(c, context.Background(), org.Id, env.Id, 10*time.Millisecond)
+	assert.True(t, errors.Is(err, ErrorTimeOutOnEnvironment))
+}